@@ -12,6 +12,7 @@ const (
 	cShowHelp = iota
 	cCheckDigit
 	cParseValidate
+	cRangeInfo
 )
 
 func croak(msg string) {
@@ -30,7 +31,7 @@ func main() {
 		showHelp()
 	}
 
-	if len(inputs) == 0 {
+	if len(inputs) == 0 && action != cRangeInfo {
 		croak("No ISBN supplied.")
 	}
 
@@ -51,6 +52,10 @@ func main() {
 			croak(fmt.Sprintf("%s", err))
 		}
 
+		if action == cRangeInfo {
+			showRangeInfo()
+		}
+
 		for _, val := range inputs {
 			checkISBN(val)
 		}
@@ -69,6 +74,10 @@ func parseArgs() (action int, inputs []string) {
 			if action == 0 {
 				action = cParseValidate
 			}
+		} else if val == "-i" {
+			if action == 0 {
+				action = cRangeInfo
+			}
 		} else if val == "-h" {
 			showHelp()
 		} else {
@@ -104,13 +113,25 @@ func checkISBN(input string) {
 	fmt.Println(result)
 }
 
+func showRangeInfo() {
+	info := isbn.RangeMessageInfo()
+	fmt.Printf("Range data source: %s\n", info.Source)
+	fmt.Printf("Range data serial number: %s\n", info.SerialNumber)
+	fmt.Printf("Range data date: %s\n", info.Date)
+}
+
 func showHelp() {
 
 	fmt.Println(os.Args[0])
-	fmt.Println("  Usage [-c|-p] isbn [isbn [isbn ...]]")
+	fmt.Println("  Usage [-c|-p|-i] isbn [isbn [isbn ...]]")
 	fmt.Println()
 	fmt.Println("    -h Show help")
 	fmt.Println("    -c Calculate check-digit(s) (does not parse/validate)")
 	fmt.Println("    -p Parse and validate ISBN(s)")
+	fmt.Println("    -i Show the range data source, serial number and date (then")
+	fmt.Println("       parse and validate any ISBN(s))")
+	fmt.Println()
+	fmt.Println("  The ISBN_RANGE_FILE Env variable must be set to the RangeMessage.xml")
+	fmt.Println("  file to parse with (except for -c).")
 	os.Exit(0)
 }
@@ -0,0 +1,152 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+)
+
+// An Allocator hands out the ISBNs of a registrant's block in
+// sequential (publication number) order, skipping any publication
+// numbers that have already been used. An Allocator is not safe for
+// concurrent use.
+type Allocator struct {
+	r          *RangeData
+	prefix     string
+	group      string
+	registrant string
+	width      int
+	limit      int
+	next       int
+	used       map[int]bool
+	ahead      int // the number of used publication numbers at or after next
+}
+
+// NewAllocator returns an Allocator for the block of the registrant in
+// the prefix and registration group using the range data loaded by
+// LoadRangeData.
+func NewAllocator(prefix, group, registrant string) (*Allocator, error) {
+	return defaultRangeData.NewAllocator(prefix, group, registrant)
+}
+
+// NewAllocator returns an Allocator for the block of the registrant in
+// the prefix and registration group using the range data in r. An
+// error is returned if the registrant is not in an allocated range of
+// the registration group.
+func (r *RangeData) NewAllocator(prefix, group, registrant string) (*Allocator, error) {
+
+	t := r.rangeTable()
+	if len(t.rmd) == 0 {
+		return nil, ErrNoRangeData
+	}
+
+	rs, ok := t.rmd[prefix][group]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s-%s", ErrUnknownGroup, prefix, group)
+	}
+
+	chk, err := toInt([]byte(registrant))
+	if err != nil || registrant == "" {
+		return nil, fmt.Errorf("%w: %s-%s-%s", ErrRegistrantNotAllocated, prefix, group, registrant)
+	}
+
+	found := false
+	for _, rg := range rs.Ranges {
+		if len(registrant) == rg[2] && chk >= rg[0] && chk <= rg[1] {
+			found = true
+		}
+	}
+
+	width := elementDigits - len(group) - len(registrant)
+	if !found || width < 1 {
+		return nil, fmt.Errorf("%w: %s-%s-%s", ErrRegistrantNotAllocated, prefix, group, registrant)
+	}
+
+	a := &Allocator{
+		r:          r,
+		prefix:     prefix,
+		group:      group,
+		registrant: registrant,
+		width:      width,
+		limit:      pow10(width),
+		used:       make(map[int]bool),
+	}
+	return a, nil
+}
+
+// Width returns the width (number of digits) of the publication
+// numbers in the registrant's block.
+func (a *Allocator) Width() int {
+	return a.width
+}
+
+// Remaining returns the number of ISBNs in the block that have not yet
+// been allocated (or marked as used).
+func (a *Allocator) Remaining() int {
+	return a.limit - a.next - a.ahead
+}
+
+// MarkUsed marks the publication numbers as already used so that they
+// will not be allocated. Each publication number must be Width digits
+// long.
+func (a *Allocator) MarkUsed(publications ...string) error {
+	for _, pub := range publications {
+		n, err := toInt([]byte(pub))
+		if err != nil || len(pub) != a.width {
+			return fmt.Errorf("invalid publication number %q (want %d digits)", pub, a.width)
+		}
+		if a.used[n] {
+			continue
+		}
+		a.used[n] = true
+		if n >= a.next {
+			a.ahead++
+		}
+	}
+	return nil
+}
+
+// Next returns the next unused ISBN in the registrant's block. Both the
+// ISBN-13 and, for the 978 prefix, the ISBN-10 forms are available from
+// the returned ISBN. ErrBlockExhausted is returned once every
+// publication number in the block has been allocated.
+func (a *Allocator) Next() (ISBN, error) {
+
+	for a.next < a.limit && a.used[a.next] {
+		a.next++
+		a.ahead--
+	}
+	if a.next >= a.limit {
+		return ISBN{}, fmt.Errorf("%w: %s-%s-%s", ErrBlockExhausted, a.prefix, a.group, a.registrant)
+	}
+
+	pub := fmt.Sprintf("%0*d", a.width, a.next)
+	a.used[a.next] = true
+	a.next++
+
+	s := a.prefix + a.group + a.registrant + pub + "0"
+	cd, err := CalcCheckDigit13(s)
+	if err != nil {
+		return ISBN{}, err
+	}
+
+	return a.r.ParseISBN(s[:len(s)-1] + cd)
+}
+
+// NextN returns the next n unused ISBNs in the registrant's block. If
+// the block is exhausted first then the ISBNs that could be allocated
+// are returned along with ErrBlockExhausted.
+func (a *Allocator) NextN(n int) ([]ISBN, error) {
+
+	ret := make([]ISBN, 0, n)
+	for i := 0; i < n; i++ {
+		x, err := a.Next()
+		if err != nil {
+			return ret, err
+		}
+		ret = append(ret, x)
+	}
+	return ret, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAllocator(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	// 0-8225 has a block of 10000 (4 digit) publication numbers
+	a, err := NewAllocator("978", "0", "8225")
+	if err != nil {
+		t.Fatalf("NewAllocator() == fail, want success (%q)", err)
+	}
+	if a.Width() != 4 {
+		t.Errorf("Allocator.Width() == %d, want %d", a.Width(), 4)
+	}
+
+	err = a.MarkUsed("0000", "0002")
+	if err != nil {
+		t.Errorf("Allocator.MarkUsed() == fail, want success (%q)", err)
+	}
+	err = a.MarkUsed("12")
+	if err == nil {
+		t.Errorf("Allocator.MarkUsed(%q) == success, want fail", "12")
+	}
+
+	got, err := a.NextN(3)
+	if err != nil {
+		t.Fatalf("Allocator.NextN(3) == fail, want success (%q)", err)
+	}
+	want := []struct {
+		isbn13 string
+		isbn10 string
+	}{
+		{"9780822500018", "0822500019"},
+		{"9780822500032", "0822500035"},
+		{"9780822500049", "0822500043"},
+	}
+	for i, w := range want {
+		if got[i].ISBN13() != w.isbn13 || got[i].ISBN10() != w.isbn10 {
+			t.Errorf("Allocator.NextN(3)[%d] == %s/%s, want %s/%s", i, got[i].ISBN13(), got[i].ISBN10(), w.isbn13, w.isbn10)
+		}
+	}
+	if a.Remaining() != 10000-5 {
+		t.Errorf("Allocator.Remaining() == %d, want %d", a.Remaining(), 10000-5)
+	}
+
+	// Publication numbers marked as used before (or again) are not
+	// counted twice
+	err = a.MarkUsed("0001", "0002", "0007", "0007", "9999")
+	if err != nil {
+		t.Errorf("Allocator.MarkUsed() == fail, want success (%q)", err)
+	}
+	if a.Remaining() != 10000-7 {
+		t.Errorf("Allocator.Remaining() == %d, want %d", a.Remaining(), 10000-7)
+	}
+	got, err = a.NextN(3)
+	if err != nil || got[2].ISBN13() != "9780822500087" {
+		t.Errorf("Allocator.NextN(3)[2] == %s (%v), want %s", got[2].ISBN13(), err, "9780822500087")
+	}
+	if a.Remaining() != 10000-10 {
+		t.Errorf("Allocator.Remaining() == %d, want %d", a.Remaining(), 10000-10)
+	}
+
+	// 0-9999999 has a block of 10 publication numbers
+	a, err = NewAllocator("978", "0", "9999999")
+	if err != nil {
+		t.Fatalf("NewAllocator() == fail, want success (%q)", err)
+	}
+	got, err = a.NextN(11)
+	if !errors.Is(err, ErrBlockExhausted) {
+		t.Errorf("Allocator.NextN(11) == %v, want ErrBlockExhausted", err)
+	}
+	if len(got) != 10 {
+		t.Errorf("Allocator.NextN(11) returned %d ISBNs, want 10", len(got))
+	}
+
+	// 979 ISBNs have no ISBN-10
+	a, err = NewAllocator("979", "10", "12")
+	if err != nil {
+		t.Fatalf("NewAllocator() == fail, want success (%q)", err)
+	}
+	x, err := a.Next()
+	if err != nil || x.ISBN13() != "9791012000003" || x.ISBN10() != "" {
+		t.Errorf("Allocator.Next() == %s/%q (%v), want %s/%q", x.ISBN13(), x.ISBN10(), err, "9791012000003", "")
+	}
+
+	failures := []struct {
+		prefix     string
+		group      string
+		registrant string
+		want       error
+	}{
+		{"978", "6", "12", ErrUnknownGroup},
+		{"979", "12", "01", ErrRegistrantNotAllocated},
+		{"978", "0", "820", ErrRegistrantNotAllocated},
+		{"978", "0", "", ErrRegistrantNotAllocated},
+	}
+	for _, c := range failures {
+		_, err := NewAllocator(c.prefix, c.group, c.registrant)
+		if !errors.Is(err, c.want) {
+			t.Errorf("NewAllocator(%q, %q, %q) == %v, want %v", c.prefix, c.group, c.registrant, err, c.want)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
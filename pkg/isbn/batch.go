@@ -0,0 +1,161 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// A Result is the result of validating (parsing) one of a batch of
+// ISBNs.
+type Result struct {
+	Index int    // the position of the input in the batch
+	Input string // the input as supplied
+	ISBN  ISBN   // the parsed ISBN
+	Err   error  // the error from ParseISBN, if any
+}
+
+// job is an input, and its position, to be validated by a worker.
+type job struct {
+	index int
+	input string
+}
+
+// ValidateBatch validates the inputs using the range data loaded by
+// LoadRangeData. See RangeData.ValidateBatch.
+func ValidateBatch(ctx context.Context, inputs []string, workers int) ([]Result, error) {
+	return defaultRangeData.ValidateBatch(ctx, inputs, workers)
+}
+
+// ValidateBatch validates (parses) the inputs using the range data in r
+// across the specified number of goroutines (GOMAXPROCS if workers is
+// less than 1). The results are returned in input order. If ctx is
+// cancelled before all of the inputs have been validated then ctx.Err()
+// is returned along with the (incomplete) results; results for inputs
+// that were not validated have an Index of -1.
+func (r *RangeData) ValidateBatch(ctx context.Context, inputs []string, workers int) ([]Result, error) {
+
+	ret := make([]Result, len(inputs))
+	for i := range ret {
+		ret[i].Index = -1
+	}
+
+	i := 0
+	next := func() (string, bool) {
+		if i >= len(inputs) {
+			return "", false
+		}
+		i++
+		return inputs[i-1], true
+	}
+
+	n := 0
+	for res := range r.validate(ctx, next, workers) {
+		ret[res.Index] = res
+		n++
+	}
+
+	// A cancellation after the last input was validated doesn't matter
+	if n < len(inputs) {
+		return ret, ctx.Err()
+	}
+	return ret, nil
+}
+
+// ValidateStream validates the inputs using the range data loaded by
+// LoadRangeData. See RangeData.ValidateStream.
+func ValidateStream(ctx context.Context, inputs <-chan string, workers int) <-chan Result {
+	return defaultRangeData.ValidateStream(ctx, inputs, workers)
+}
+
+// ValidateStream validates (parses) the inputs received from the
+// inputs channel using the range data in r across the specified number
+// of goroutines (GOMAXPROCS if workers is less than 1). The results
+// are sent, as they become available (so not necessarily in input
+// order), on the returned channel which is closed once inputs is closed
+// and all of its inputs have been validated or once ctx is cancelled.
+// The returned channel must be drained, or ctx cancelled, so that the
+// goroutines can exit.
+func (r *RangeData) ValidateStream(ctx context.Context, inputs <-chan string, workers int) <-chan Result {
+
+	next := func() (string, bool) {
+		select {
+		case <-ctx.Done():
+			return "", false
+		case s, ok := <-inputs:
+			return s, ok
+		}
+	}
+
+	return r.validate(ctx, next, workers)
+}
+
+// ValidateFunc validates the inputs using the range data loaded by
+// LoadRangeData. See RangeData.ValidateFunc.
+func ValidateFunc(ctx context.Context, next func() (string, bool), workers int) <-chan Result {
+	return defaultRangeData.ValidateFunc(ctx, next, workers)
+}
+
+// ValidateFunc is as ValidateStream except that the inputs are obtained
+// by calling next (an iterator) until it returns false. next is only
+// ever called from a single goroutine.
+func (r *RangeData) ValidateFunc(ctx context.Context, next func() (string, bool), workers int) <-chan Result {
+	return r.validate(ctx, next, workers)
+}
+
+// validate feeds the inputs returned by next to a pool of workers that
+// parse them and send the results on the returned channel.
+func (r *RangeData) validate(ctx context.Context, next func() (string, bool), workers int) <-chan Result {
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan job, workers)
+	results := make(chan Result, workers)
+
+	go func() {
+		defer close(jobs)
+		for i := 0; ; i++ {
+			if ctx.Err() != nil {
+				return
+			}
+			s, ok := next()
+			if !ok {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- job{index: i, input: s}:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				x, err := r.ParseISBN(j.input)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- Result{Index: j.index, Input: j.input, ISBN: x, Err: err}:
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// batchCases are the inputs for the batch tests, and whether or not
+// they are valid
+var batchCases = []struct {
+	in   string
+	want bool
+}{
+	{"88 04 47328 2", true},
+	{"978-8804473282", true},
+	{"0547928246", true},
+	{"978-0547928241", true},
+	{"978 0670013951", true},
+	{"089686281x", true},
+	{"9780822527602", true},
+	{"9780590d32053", false},
+	{"978-8891230195", true},
+	{"9780590132053F", false},
+	{"9780590732053", false},
+	{"081666303x", false},
+	{"", false},
+}
+
+func TestValidateBatch(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	var inputs []string
+	for i := 0; i < 100; i++ {
+		for _, c := range batchCases {
+			inputs = append(inputs, c.in)
+		}
+	}
+
+	for _, workers := range []int{0, 1, 7} {
+		got, err := ValidateBatch(context.Background(), inputs, workers)
+		if err != nil {
+			t.Fatalf("ValidateBatch(%d) == fail, want success (%q)", workers, err)
+		}
+		if len(got) != len(inputs) {
+			t.Fatalf("ValidateBatch(%d) returned %d results, want %d", workers, len(got), len(inputs))
+		}
+		for i, res := range got {
+			c := batchCases[i%len(batchCases)]
+			if res.Index != i || res.Input != c.in {
+				t.Errorf("ValidateBatch(%d)[%d] == {%d, %q}, want {%d, %q}", workers, i, res.Index, res.Input, i, c.in)
+			}
+			if (res.Err == nil) != c.want {
+				t.Errorf("ValidateBatch(%d)[%d] (%q) error == %v, want valid %t", workers, i, c.in, res.Err, c.want)
+			}
+		}
+	}
+
+	// A cancelled context stops the validation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ValidateBatch(ctx, inputs, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateBatch() with a cancelled context == %v, want context.Canceled", err)
+	}
+
+	// The cancellation doesn't matter once every input is validated
+	res, err := ValidateBatch(ctx, nil, 4)
+	if err != nil || len(res) != 0 {
+		t.Errorf("ValidateBatch() with no inputs and a cancelled context == %v, want nil", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestValidateStream(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	in := make(chan string)
+	go func() {
+		for _, c := range batchCases {
+			in <- c.in
+		}
+		close(in)
+	}()
+
+	seen := make(map[int]bool)
+	for res := range ValidateStream(context.Background(), in, 3) {
+		c := batchCases[res.Index]
+		if res.Input != c.in || (res.Err == nil) != c.want {
+			t.Errorf("ValidateStream() result %d == {%q, %v}, want {%q, valid %t}", res.Index, res.Input, res.Err, c.in, c.want)
+		}
+		seen[res.Index] = true
+	}
+	if len(seen) != len(batchCases) {
+		t.Errorf("ValidateStream() returned %d results, want %d", len(seen), len(batchCases))
+	}
+
+	// Iterator input, cancelled part way through
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	next := func() (string, bool) {
+		return "0547928246", true
+	}
+	count := 0
+	for range ValidateFunc(ctx, next, 2) {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+	if count < 10 {
+		t.Errorf("ValidateFunc() returned %d results before cancelling, want at least 10", count)
+	}
+
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+)
+
+// elementDigits is the number of digits shared by the registration
+// group, registrant and publication elements of an ISBN (thirteen less
+// the three digit prefix and the check digit).
+const elementDigits = 9
+
+// A RegistrantRange describes one of the ranges of registrant elements
+// that are allocated within a registration group.
+type RegistrantRange struct {
+	Start        string // the first registrant element in the range
+	End          string // the last registrant element in the range
+	Length       int    // the length of the registrant elements
+	Registrants  int    // the number of registrants in the range
+	Publications int    // the number of publication numbers per registrant
+}
+
+// RegistrantRanges lists the allocated registrant ranges for the
+// prefix and registration group using the range data loaded by
+// LoadRangeData.
+func RegistrantRanges(prefix, group string) ([]RegistrantRange, error) {
+	return defaultRangeData.RegistrantRanges(prefix, group)
+}
+
+// RegistrantRanges lists the allocated registrant ranges for the
+// prefix and registration group using the range data in r. The ranges
+// are listed in the order that they appear in the range data.
+func (r *RangeData) RegistrantRanges(prefix, group string) ([]RegistrantRange, error) {
+
+	t := r.rangeTable()
+	if len(t.rmd) == 0 {
+		return nil, ErrNoRangeData
+	}
+
+	rs, ok := t.rmd[prefix][group]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s-%s", ErrUnknownGroup, prefix, group)
+	}
+
+	ret := make([]RegistrantRange, 0, len(rs.Ranges))
+	for _, rg := range rs.Ranges {
+		rStart := rg[0]
+		rEnd := rg[1]
+		rLen := rg[2]
+
+		ret = append(ret, RegistrantRange{
+			Start:        fmt.Sprintf("%0*d", rLen, rStart),
+			End:          fmt.Sprintf("%0*d", rLen, rEnd),
+			Length:       rLen,
+			Registrants:  rEnd - rStart + 1,
+			Publications: pow10(elementDigits - len(group) - rLen),
+		})
+	}
+	return ret, nil
+}
+
+// BlockSize returns the number of publication numbers in the block
+// allocated to the registrant of the ISBN (or 0 if the ISBN is not
+// valid).
+func (x ISBN) BlockSize() int {
+	if !x.IsValid {
+		return 0
+	}
+	return pow10(len(x.Publication))
+}
+
+// pow10 returns 10 to the power of n (or 0 for a negative n).
+func pow10(n int) int {
+	if n < 0 {
+		return 0
+	}
+	ret := 1
+	for i := 0; i < n; i++ {
+		ret *= 10
+	}
+	return ret
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistrantRanges(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	got, err := RegistrantRanges("978", "0")
+	if err != nil {
+		t.Fatalf("RegistrantRanges(%q, %q) == fail, want success (%q)", "978", "0", err)
+	}
+	if len(got) == 0 {
+		t.Fatalf("RegistrantRanges(%q, %q) == no ranges, want ranges", "978", "0")
+	}
+
+	// The first English language range is 00-19 (twenty registrants
+	// with a million publication numbers each)
+	want := RegistrantRange{Start: "00", End: "19", Length: 2, Registrants: 20, Publications: 1000000}
+	if got[0] != want {
+		t.Errorf("RegistrantRanges(%q, %q)[0] == %+v, want %+v", "978", "0", got[0], want)
+	}
+
+	for _, rr := range got {
+		if len(rr.Start) != rr.Length || len(rr.End) != rr.Length {
+			t.Errorf("RegistrantRange %+v: Start/End not of length %d", rr, rr.Length)
+		}
+		if rr.Registrants <= 0 || rr.Publications <= 0 {
+			t.Errorf("RegistrantRange %+v: want positive capacities", rr)
+		}
+	}
+
+	_, err = RegistrantRanges("978", "6")
+	if !errors.Is(err, ErrUnknownGroup) {
+		t.Errorf("RegistrantRanges(%q, %q) == %v, want ErrUnknownGroup", "978", "6", err)
+	}
+
+	_, _ = UnloadRangeData()
+
+	_, err = RegistrantRanges("978", "0")
+	if !errors.Is(err, ErrNoRangeData) {
+		t.Errorf("RegistrantRanges(%q, %q) with no range data == %v, want ErrNoRangeData", "978", "0", err)
+	}
+}
+
+func TestBlockSize(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in   string
+		want int
+	}{
+		{"88 04 47328 2", 100000},
+		{"978-0547928241", 100000},
+		{"089686281x", 1000},
+		{"9780822527602", 10000},
+		{"9780590732053", 0},
+	}
+	for _, c := range cases {
+		isbn, _ := ParseISBN(c.in)
+		got := isbn.BlockSize()
+		if got != c.want {
+			t.Errorf("ParseISBN(%q).BlockSize() == %d, want %d", c.in, got, c.want)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
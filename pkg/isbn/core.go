@@ -0,0 +1,138 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+// The functions in this file are the allocation free core of the
+// validation. They work on the bytes of the ISBN in a single pass
+// rather than building intermediate strings. Whenever they find the
+// ISBN to be invalid the (slower) string based checks are used to
+// produce the error so that the errors are exactly as they have always
+// been.
+
+// normalize copies the ISBN into dst, dropping spaces and hyphens and
+// upper-casing it as stripISBN does, and returns the number of
+// characters copied. ok is false if the ISBN has more characters than
+// fit in dst or has non-ASCII characters (in either case the ISBN is
+// invalid and the slower stripISBN is left to deal with it).
+func normalize(dst *[13]byte, isbn string) (n int, ok bool) {
+	for i := 0; i < len(isbn); i++ {
+		c := isbn[i]
+		switch {
+		case c >= 0x80:
+			return n, false
+		case c == ' ' || c == '-' || c == '\t' || c == '\n' || c == '\f' || c == '\r':
+			continue
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+		if n == len(dst) {
+			return n, false
+		}
+		dst[n] = c
+		n++
+	}
+	return n, true
+}
+
+// invalidChar returns the position of the first character that is not
+// valid in an ISBN (digits, with an X also allowed for the check
+// digit), or -1 if all of the characters are valid.
+func invalidChar[T ~string | ~[]byte](b T) int {
+	last := len(b) - 1
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c >= '0' && c <= '9' {
+			continue
+		}
+		if c == 'X' && i == last {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// checkDigit10 returns the ISBN-10 check digit for the first nine
+// digits of b. The digits must have been checked by invalidChar.
+func checkDigit10[T ~string | ~[]byte](b T) byte {
+	var sum int
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(b[i]-'0')
+	}
+	rem := (11 - sum%11) % 11
+	if rem == 10 {
+		return 'X'
+	}
+	return byte('0' + rem)
+}
+
+// checkDigit13 returns the ISBN-13 check digit for the first twelve
+// digits of b. The digits must have been checked by invalidChar.
+func checkDigit13[T ~string | ~[]byte](b T) byte {
+	var sum int
+	for i := 0; i < 12; i += 2 {
+		sum += int(b[i]-'0') + 3*int(b[i+1]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// checkDigitString returns the check digit c as a string (without
+// allocating).
+func checkDigitString(c byte) string {
+	if c == 'X' {
+		return "X"
+	}
+	i := c - '0'
+	return "0123456789"[i : i+1]
+}
+
+// checkDigit returns the check digit for b which must be 10 or 13
+// characters long.
+func checkDigit[T ~string | ~[]byte](b T) byte {
+	if len(b) == 10 {
+		return checkDigit10(b)
+	}
+	return checkDigit13(b)
+}
+
+// quickCheck normalises the ISBN into dst and checks the length,
+// characters and check digit of it. ok is true if the ISBN passes all
+// of the checks.
+func quickCheck(dst *[13]byte, isbn string) (n int, ok bool) {
+	n, ok = normalize(dst, isbn)
+	if !ok || (n != 10 && n != 13) {
+		return n, false
+	}
+	b := dst[:n]
+	if invalidChar(b) >= 0 {
+		return n, false
+	}
+	return n, checkDigit(b) == b[n-1]
+}
+
+// validationError returns the error for an ISBN that failed
+// quickCheck using the original, string based, checks.
+func validationError(isbn string) error {
+
+	isbn = stripISBN(isbn)
+
+	err := chkLength(isbn)
+	if err != nil {
+		return err
+	}
+
+	err = chkCharacters(isbn)
+	if err != nil {
+		return err
+	}
+
+	_, err = chkCheckDigit(isbn)
+	if err != nil {
+		return err
+	}
+
+	// Not reached as quickCheck and the string based checks agree
+	return newParseError(StageCharacters, isbn, -1, ErrInvalidCharacter)
+}
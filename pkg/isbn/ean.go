@@ -0,0 +1,69 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+// EANKind identifies what an EAN-13 is the number of, as determined by
+// its prefix.
+type EANKind int
+
+// The kinds of EAN-13 that ClassifyEAN distinguishes.
+const (
+	// EANOther is an EAN-13 that is neither a book, music nor a serial
+	// (i.e. any other product).
+	EANOther EANKind = iota
+	// EANBook is an ISBN-13 (the 978 and 979 prefixes other than
+	// 979-0).
+	EANBook
+	// EANMusic is an ISMN (the 979-0 prefix).
+	EANMusic
+	// EANSerial is the EAN-13 form of an ISSN (the 977 prefix).
+	EANSerial
+)
+
+// String implements the Stringer interface.
+func (k EANKind) String() string {
+	switch k {
+	case EANOther:
+		return "other"
+	case EANBook:
+		return "book"
+	case EANMusic:
+		return "music"
+	case EANSerial:
+		return "serial"
+	}
+	return "unknown"
+}
+
+// ClassifyEAN returns the kind of the EAN-13 from its prefix. The EAN
+// is only checked for length, characters and check digit (spaces and
+// hyphens being ignored) so, for example, an EANBook is not
+// necessarily an ISBN that ParseISBN accepts. The errors are
+// *ParseErrors as for ParseISBN.
+func ClassifyEAN(ean string) (EANKind, error) {
+
+	ean = stripISBN(ean)
+
+	if len(ean) != 13 {
+		return EANOther, newParseError(StageLength, ean, -1, ErrInvalidLength)
+	}
+	err := chkDigits(ean, 13)
+	if err != nil {
+		return EANOther, err
+	}
+	if checkDigit13(ean) != ean[12] {
+		return EANOther, newParseError(StageCheckDigit, ean, 12, ErrInvalidCheckDigit)
+	}
+
+	switch {
+	case ean[:4] == pISMN:
+		return EANMusic, nil
+	case ean[:3] == p978 || ean[:3] == p979:
+		return EANBook, nil
+	case ean[:3] == pISSN:
+		return EANSerial, nil
+	}
+	return EANOther, nil
+}
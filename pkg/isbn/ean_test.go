@@ -0,0 +1,38 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyEAN(t *testing.T) {
+
+	cases := []struct {
+		in   string
+		want EANKind
+		err  error
+	}{
+		{"9780547928241", EANBook, nil},
+		{"979-10-12-34567-8", EANBook, nil},
+		{"9790260000438", EANMusic, nil},
+		{"9770317847001", EANSerial, nil},
+		{"9771234567003", EANSerial, nil},
+		{"4006381333931", EANOther, nil},
+		{"9780547928242", EANOther, ErrInvalidCheckDigit},
+		{"0547928246", EANOther, ErrInvalidLength},
+		{"978054792824X", EANOther, ErrInvalidCharacter},
+	}
+	for _, c := range cases {
+		got, err := ClassifyEAN(c.in)
+		if got != c.want {
+			t.Errorf("ClassifyEAN(%q) == %s, want %s", c.in, got, c.want)
+		}
+		if !errors.Is(err, c.err) {
+			t.Errorf("ClassifyEAN(%q) error == %v, want %v", c.in, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors that validating or parsing an ISBN may fail with. The
+// errors returned by ParseISBN (and friends) are *ParseErrors that wrap
+// one of these so that errors.Is may be used for determining why an
+// ISBN was rejected.
+var (
+	// ErrInvalidLength indicates that the ISBN is not 10 or 13
+	// characters long (once spaces and hyphens are removed).
+	ErrInvalidLength = errors.New("ISBN length is incorrect")
+
+	// ErrInvalidCharacter indicates that the ISBN contains a character
+	// that is not a digit (or an X for the check digit).
+	ErrInvalidCharacter = errors.New("invalid character found in ISBN")
+
+	// ErrInvalidCheckDigit indicates that the check digit of the ISBN
+	// does not match the calculated check digit.
+	ErrInvalidCheckDigit = errors.New("ISBN check digit is incorrect")
+
+	// ErrNoRangeData indicates that no range data has been loaded so
+	// the ISBN could not be parsed.
+	ErrNoRangeData = errors.New("no range data for parsing ISBNs (perhaps you did not LoadRangeData)")
+
+	// ErrUnknownPrefix indicates that the EAN.UCC prefix of the ISBN
+	// is not allocated to ISBNs.
+	ErrUnknownPrefix = errors.New("EAN.UCC prefix not allocated")
+
+	// ErrUnknownGroup indicates that the registration group of the
+	// ISBN is either not allocated or not in the range data.
+	ErrUnknownGroup = errors.New("registration group not allocated")
+
+	// ErrRegistrantNotAllocated indicates that the registrant element
+	// of the ISBN falls outside of the allocated registrant ranges for
+	// the registration group (either in an unused range or in no range
+	// at all) so the ISBN cannot have been assigned.
+	ErrRegistrantNotAllocated = errors.New("registrant range not allocated")
+
+	// ErrNotValid indicates that an ISBN has not been (successfully)
+	// parsed so it cannot be formatted.
+	ErrNotValid = errors.New("ISBN is not valid")
+
+	// ErrNoISBN10 indicates that an ISBN has no ISBN-10 form (only
+	// ISBNs with the 978 prefix do).
+	ErrNoISBN10 = errors.New("ISBN has no ISBN-10 form")
+
+	// ErrBlockExhausted indicates that all of the publication numbers
+	// in a registrant's block have been allocated.
+	ErrBlockExhausted = errors.New("registrant block exhausted")
+
+	// ErrHyphenation indicates that an ISBN is not hyphenated according
+	// to the range data (see ParseISBNStrict).
+	ErrHyphenation = errors.New("ISBN hyphenation is incorrect")
+
+	// ErrISMN indicates that an ISBN has the 979-0 prefix which is
+	// allocated to International Standard Music Numbers (see ParseISMN)
+	// rather than to books.
+	ErrISMN = errors.New("979-0 prefix is allocated to ISMNs, not ISBNs")
+
+	// ErrNoSBN indicates that an ISBN has no SBN form (only ISBNs in
+	// the 978-0 registration group do).
+	ErrNoSBN = errors.New("ISBN has no SBN form")
+)
+
+// Stage identifies the stage of validating or parsing an ISBN at which
+// a ParseError occurred.
+type Stage string
+
+// The stages of validating and parsing an ISBN.
+const (
+	StageLength     Stage = "length"
+	StageCharacters Stage = "characters"
+	StageCheckDigit Stage = "check digit"
+	StageRangeData  Stage = "range data"
+	StagePrefix     Stage = "prefix"
+	StageGroup      Stage = "registration group"
+	StageRegistrant Stage = "registrant"
+)
+
+// A ParseError records why an ISBN failed validation or parsing.
+type ParseError struct {
+	Stage Stage  // the stage at which the ISBN was rejected
+	Input string // the normalised (stripped, upper-cased) ISBN
+	Pos   int    // the position in Input of the offending character or element, -1 if not applicable
+	Err   error  // the reason for the rejection (one of the Err... values)
+}
+
+// newParseError returns a ParseError for the specified stage.
+func newParseError(stage Stage, input string, pos int, err error) *ParseError {
+	return &ParseError{Stage: stage, Input: input, Pos: pos, Err: err}
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	msg := e.Err.Error()
+	if e.Pos >= 0 {
+		msg = fmt.Sprintf("%s at position %d", msg, e.Pos+1)
+	}
+	return fmt.Sprintf("%s in %q", msg, e.Input)
+}
+
+// Unwrap returns the underlying reason for the ParseError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+
+	// Test the errors without range data
+	var empty RangeData
+	_, err := empty.ParseISBN("978-0547928241")
+	if !errors.Is(err, ErrNoRangeData) {
+		t.Errorf("RangeData.ParseISBN() with no range data == %v, want ErrNoRangeData", err)
+	}
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in    string
+		want  error
+		stage Stage
+		input string
+		pos   int
+	}{
+		{"978-05479282", ErrInvalidLength, StageLength, "97805479282", -1},
+		{"", ErrInvalidLength, StageLength, "", -1},
+		{"97805S0132053", ErrInvalidCharacter, StageCharacters, "97805S0132053", 5},
+		{"978-059013205F", ErrInvalidCharacter, StageCharacters, "978059013205F", 12},
+		{"08X6686281", ErrInvalidCharacter, StageCharacters, "08X6686281", 2},
+		{"9780590732053", ErrInvalidCheckDigit, StageCheckDigit, "9780590732053", 12},
+		{"081666303x", ErrInvalidCheckDigit, StageCheckDigit, "081666303X", 9},
+		{"9771234567003", ErrUnknownPrefix, StagePrefix, "9771234567003", 0},
+	}
+	for _, c := range cases {
+		_, err := ParseISBN(c.in)
+		if !errors.Is(err, c.want) {
+			t.Errorf("ParseISBN(%q) == %v, want %v", c.in, err, c.want)
+			continue
+		}
+
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("ParseISBN(%q) error is not a *ParseError", c.in)
+			continue
+		}
+		if pe.Stage != c.stage || pe.Input != c.input || pe.Pos != c.pos {
+			t.Errorf("ParseISBN(%q) == {%q, %q, %d}, want {%q, %q, %d}", c.in, pe.Stage, pe.Input, pe.Pos, c.stage, c.input, c.pos)
+		}
+	}
+
+	// The group and registrant errors depend on which groups and
+	// registrant ranges are in the range data
+	var rd RangeData
+	_, err = rd.LoadRangeDataBytes([]byte(minimalXML))
+	if err != nil {
+		t.Fatalf("RangeData.LoadRangeDataBytes() failed (%q)", err)
+	}
+
+	shaped := []struct {
+		in    string
+		want  error
+		stage Stage
+		pos   int
+	}{
+		{"9795123456780", ErrUnknownGroup, StageGroup, 3},
+		{"978-91-12345-67-4", ErrUnknownGroup, StageGroup, 3},
+		{"9791021234567", ErrRegistrantNotAllocated, StageRegistrant, 5},
+		{"9021234564", ErrRegistrantNotAllocated, StageRegistrant, 2},
+	}
+	for _, c := range shaped {
+		_, err := rd.ParseISBN(c.in)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, c.want) {
+			t.Errorf("RangeData.ParseISBN(%q) == %v, want %v", c.in, err, c.want)
+			continue
+		}
+		if pe.Stage != c.stage || pe.Pos != c.pos {
+			t.Errorf("RangeData.ParseISBN(%q) == {%q, %d}, want {%q, %d}", c.in, pe.Stage, pe.Pos, c.stage, c.pos)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
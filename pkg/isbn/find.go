@@ -0,0 +1,217 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Match is an ISBN found in text by FindAll.
+type Match struct {
+	Start int    // the byte offset of the start of the match (including any label)
+	End   int    // the byte offset of the end of the match
+	Raw   string // the matched text
+	Label string // the label that preceded the ISBN ("ISBN", "ISBN-13", etc.), if any
+	ISBN  ISBN   // the parsed ISBN
+	Err   error  // the error from parsing the ISBN, if any
+}
+
+// findRE matches candidate ISBNs in text. A candidate is a run of at
+// least nine digits (and an optional X check digit) with at most one
+// separator (space, tab, hyphen or dash) between each pair of digits.
+// The candidate is either preceded by an "ISBN", "ISBN-10" or "ISBN-13"
+// label or starts at a word boundary.
+var findRE = regexp.MustCompile(`(?i)(?:\b(ISBN(?:[- ]?1[03])?)[ \t]*[:#]?[ \t]*|\b)` +
+	`([0-9](?:[ \t\-\x{00A0}\x{2010}-\x{2015}]?[0-9]){8,}(?:[ \t\-\x{00A0}\x{2010}-\x{2015}]?X)?)`)
+
+// FindAll finds the ISBNs in the text and parses them using the range
+// data loaded by LoadRangeData.
+func FindAll(text string) []Match {
+	return defaultRangeData.FindAll(text)
+}
+
+// FindAll finds the ISBNs in the text and parses them using the range
+// data in r. The elements of an ISBN may be separated by spaces,
+// hyphens or dashes (or any mix of them).
+//
+// Labelled candidates (such as "ISBN-13: 978-0-670-01395-1") are always
+// returned, along with the error if they do not parse. Unlabelled
+// candidates are only returned when they have ten or thirteen digits
+// and a valid check digit so that other numbers (phone numbers, order
+// numbers, etc.) are not mistaken for ISBNs; they may still fail to
+// parse against the range data, in which case Err is set.
+//
+// Where a run of digits is too long to be an ISBN (such as an ISBN
+// that is followed by a year, or preceded by a volume or page number)
+// the first part of the run that starts and ends at a separator and
+// has the thirteen (or ten) digits and valid check digit of an ISBN is
+// used. For a labelled candidate only the end of the run is trimmed.
+func (r *RangeData) FindAll(text string) []Match {
+
+	var ret []Match
+
+	// The search is restarted after each candidate (rather than using
+	// FindAll) so that the remainder of a trimmed candidate is searched
+	for pos := 0; pos < len(text); {
+
+		loc := findRE.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		for i := range loc {
+			if loc[i] >= 0 {
+				loc[i] += pos
+			}
+		}
+
+		start, end := loc[0], loc[1]
+		label := ""
+		if loc[2] >= 0 {
+			label = text[loc[2]:loc[3]]
+		}
+		numStart := loc[4]
+		run := text[numStart:end]
+		pos = end
+
+		var compact string
+		if label != "" {
+			// A labelled candidate starts at the label, so only the end
+			// of it may be trimmed
+			compact = compactCandidate(run)
+			for _, w := range candidateWindows(run) {
+				if w.start == 0 {
+					compact, end = w.compact, numStart+w.end
+					break
+				}
+			}
+			pos = end
+		} else {
+			// An unlabelled candidate may have other numbers on either
+			// side of it so the first part of the run that looks like
+			// an ISBN (and does not run into a following word) is used
+			found := false
+			for _, w := range candidateWindows(run) {
+				wEnd := numStart + w.end
+				if wEnd < len(text) {
+					c, _ := utf8.DecodeRuneInString(text[wEnd:])
+					if isWordRune(c) {
+						continue
+					}
+				}
+				if ValidateCheckDigit(w.compact) {
+					compact, start, end = w.compact, numStart+w.start, wEnd
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+			pos = end
+		}
+
+		x, err := r.ParseISBN(compact)
+		ret = append(ret, Match{
+			Start: start,
+			End:   end,
+			Raw:   text[start:end],
+			Label: label,
+			ISBN:  x,
+			Err:   err,
+		})
+	}
+
+	return ret
+}
+
+// FindAllReader finds the ISBNs in the text read from rd and parses them
+// using the range data loaded by LoadRangeData.
+func FindAllReader(rd io.Reader) ([]Match, error) {
+	return defaultRangeData.FindAllReader(rd)
+}
+
+// FindAllReader finds the ISBNs in the text read from rd and parses them
+// using the range data in r. The offsets of the matches are from the
+// start of the text read.
+func (r *RangeData) FindAllReader(rd io.Reader) ([]Match, error) {
+	b, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	return r.FindAll(string(b)), nil
+}
+
+// A window is a part of a candidate ISBN (run of digits and
+// separators) that has the ten or thirteen digits of an ISBN.
+type window struct {
+	start   int    // the offset of the window in the run
+	end     int    // the offset of the end of the window in the run
+	compact string // the digits (and X) of the window
+}
+
+// candidateWindows returns the parts of the run that start at the
+// start of the run or after a separator, end at the end of the run or
+// at a separator, and have thirteen or ten digits. The windows are
+// ordered by start and then with thirteen digit windows before ten
+// digit ones (so the whole run is first when it has the digits of an
+// ISBN).
+func candidateWindows(run string) []window {
+
+	// The offsets at which windows may start and end, along with the
+	// number of digits before each of them
+	type boundary struct{ offset, digits int }
+	var starts, ends []boundary
+
+	digits := 0
+	prevSep := true
+	for i, c := range run {
+		if c >= '0' && c <= '9' || c == 'X' || c == 'x' {
+			if prevSep {
+				starts = append(starts, boundary{i, digits})
+			}
+			digits++
+			prevSep = false
+			continue
+		}
+		if !prevSep {
+			ends = append(ends, boundary{i, digits})
+		}
+		prevSep = true
+	}
+	ends = append(ends, boundary{len(run), digits})
+
+	var ret []window
+	for _, s := range starts {
+		for _, want := range []int{13, 10} {
+			for _, e := range ends {
+				if e.digits-s.digits == want {
+					ret = append(ret, window{s.offset, e.offset, compactCandidate(run[s.offset:e.offset])})
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// compactCandidate returns the digits (and X) of a candidate ISBN.
+func compactCandidate(s string) string {
+	var digits []byte
+	for _, c := range s {
+		if c >= '0' && c <= '9' || c == 'X' || c == 'x' {
+			digits = append(digits, byte(c))
+		}
+	}
+	return strings.ToUpper(string(digits))
+}
+
+// isWordRune reports whether c is a letter or digit (so that a
+// candidate ISBN followed by c is part of a longer word or number).
+func isWordRune(c rune) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
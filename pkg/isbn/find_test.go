@@ -0,0 +1,120 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	type found struct {
+		raw    string
+		label  string
+		isbn13 string
+		err    error
+	}
+
+	cases := []struct {
+		in   string
+		want []found
+	}{
+		{
+			"ISBN-13: 978-0-670-01395-1 (hardcover), ISBN 0-547-92824-6",
+			[]found{
+				{"ISBN-13: 978-0-670-01395-1", "ISBN-13", "9780670013951", nil},
+				{"ISBN 0-547-92824-6", "ISBN", "9780547928241", nil},
+			},
+		},
+		{
+			"isbn10:089686281x; also 978 0–547‒92824 1.",
+			[]found{
+				{"isbn10:089686281x", "isbn10", "9780896862814", nil},
+				{"978 0–547‒92824 1", "", "9780547928241", nil},
+			},
+		},
+		{
+			"ISBN 0-547-92824-6 1999 edition, ISBN: 978-0-670-01395-2 (misprint)",
+			[]found{
+				{"ISBN 0-547-92824-6", "ISBN", "9780547928241", nil},
+				{"ISBN: 978-0-670-01395-2", "ISBN", "", ErrInvalidCheckDigit},
+			},
+		},
+		{
+			// Numbers that are not ISBNs
+			"Call 555 123 4567 or 0800 123 4567, order 12345678901234567, ref 0547928246x",
+			nil,
+		},
+		{
+			"ISBNs 978-0-670-01395-1 0-547-92824-6 9780822527602",
+			[]found{
+				{"978-0-670-01395-1", "", "9780670013951", nil},
+				{"0-547-92824-6", "", "9780547928241", nil},
+				{"9780822527602", "", "9780822527602", nil},
+			},
+		},
+		{
+			// Short numbers before an unlabelled ISBN
+			"Vol 3 9780670013951",
+			[]found{
+				{"9780670013951", "", "9780670013951", nil},
+			},
+		},
+		{
+			"see pp. 12 0547928246 here",
+			[]found{
+				{"0547928246", "", "9780547928241", nil},
+			},
+		},
+		{
+			"vol. 2 978-0-670-01395-1 1999 and no. 7 0-547-92824-6",
+			[]found{
+				{"978-0-670-01395-1", "", "9780670013951", nil},
+				{"0-547-92824-6", "", "9780547928241", nil},
+			},
+		},
+		{
+			"ISBN 978-0-670-0139 is too short",
+			[]found{
+				{"ISBN 978-0-670-0139", "ISBN", "", ErrInvalidLength},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := FindAll(c.in)
+		if len(got) != len(c.want) {
+			t.Errorf("FindAll(%q) == %d matches (%+v), want %d", c.in, len(got), got, len(c.want))
+			continue
+		}
+		for i, w := range c.want {
+			m := got[i]
+			if m.Raw != w.raw || m.Label != w.label || m.ISBN.ISBN13() != w.isbn13 {
+				t.Errorf("FindAll(%q)[%d] == {%q, %q, %q}, want {%q, %q, %q}", c.in, i, m.Raw, m.Label, m.ISBN.ISBN13(), w.raw, w.label, w.isbn13)
+			}
+			if c.in[m.Start:m.End] != m.Raw {
+				t.Errorf("FindAll(%q)[%d] offsets [%d:%d] != %q", c.in, i, m.Start, m.End, m.Raw)
+			}
+			if w.err == nil && m.Err != nil || w.err != nil && !errors.Is(m.Err, w.err) {
+				t.Errorf("FindAll(%q)[%d] error == %v, want %v", c.in, i, m.Err, w.err)
+			}
+		}
+	}
+
+	in := "first line\nISBN 0-547-92824-6\n"
+	got, err := FindAllReader(strings.NewReader(in))
+	if err != nil || len(got) != 1 || got[0].Start != 11 {
+		t.Errorf("FindAllReader(%q) == %+v (%v), want one match at 11", in, got, err)
+	}
+
+	_, _ = UnloadRangeData()
+}
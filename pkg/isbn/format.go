@@ -0,0 +1,106 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// plainISBN has the fields of ISBN but none of the methods so that the
+// %+v and %#v verbs can print the fields.
+type plainISBN ISBN
+
+// Format implements the fmt.Formatter interface. The verbs are:
+//
+//	%v, %s  the same as String()
+//	%q      String() as a double-quoted string
+//	%n      the compact ISBN (9780547928241)
+//	%h      the hyphenated ISBN (978-0-547-92824-1)
+//
+// and the flags, for %n and %h, are:
+//
+//	#       use the ISBN-10 form instead of the ISBN-13 form
+//	' '     (space) separate the elements with spaces instead of
+//	        hyphens (978 0 547 92824 1)
+//	+       add an "ISBN " label (ISBN 978-0-547-92824-1)
+//
+// so that, for example, %+#h formats as "ISBN 0-547-92824-6". As with
+// the ISBN10 and ISBN13 methods, an ISBN that is not valid (or does not
+// have the requested form) formats as the empty string. Width and the
+// '-' flag pad the result as they do for strings.
+//
+// For debugging, %+v and %#v print the fields of the ISBN.
+func (x ISBN) Format(f fmt.State, verb rune) {
+
+	var out string
+
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%+v", plainISBN(x))
+			return
+		} else if f.Flag('#') {
+			fmt.Fprintf(f, "%#v", plainISBN(x))
+			return
+		}
+		out = x.String()
+	case 's':
+		out = x.String()
+	case 'q':
+		out = fmt.Sprintf("%q", x.String())
+	case 'n', 'h':
+		sep := ""
+		if f.Flag(' ') {
+			sep = " "
+		} else if verb == 'h' {
+			sep = "-"
+		}
+		out = x.join(f.Flag('#'), sep)
+		if out != "" && f.Flag('+') {
+			out = "ISBN " + out
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(isbn.ISBN=%s)", verb, x.String())
+		return
+	}
+
+	pad := ""
+	if w, ok := f.Width(); ok && w > len(out) {
+		pad = strings.Repeat(" ", w-len(out))
+	}
+	if f.Flag('-') {
+		fmt.Fprint(f, out+pad)
+	} else {
+		fmt.Fprint(f, pad+out)
+	}
+}
+
+// join returns the elements of the ISBN-13 (or ISBN-10 if isbn10 is
+// set) separated by sep. The empty string is returned if the ISBN is
+// not valid or does not have an ISBN-10 form.
+func (x ISBN) join(isbn10 bool, sep string) string {
+	if !x.IsValid {
+		return ""
+	}
+	if isbn10 {
+		if x.Prefix != p978 {
+			return ""
+		}
+		return strings.Join([]string{
+			x.RegistrationGroup,
+			x.Registrant,
+			x.Publication,
+			x.CheckDigit10},
+			sep)
+	}
+	return strings.Join([]string{
+		x.Prefix,
+		x.RegistrationGroup,
+		x.Registrant,
+		x.Publication,
+		x.CheckDigit13},
+		sep)
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	x, err := ParseISBN("0547928246")
+	if err != nil {
+		t.Fatalf("ParseISBN() == fail, want success (%q)", err)
+	}
+	y, err := ParseISBN("9791012345678")
+	if err != nil {
+		t.Fatalf("ParseISBN() == fail, want success (%q)", err)
+	}
+	var z ISBN
+
+	cases := []struct {
+		format string
+		in     ISBN
+		want   string
+	}{
+		{"%v", x, "978-0-547-92824-1 (0-547-92824-6)"},
+		{"%s", x, "978-0-547-92824-1 (0-547-92824-6)"},
+		{"%q", x, `"978-0-547-92824-1 (0-547-92824-6)"`},
+		{"%n", x, "9780547928241"},
+		{"%#n", x, "0547928246"},
+		{"%h", x, "978-0-547-92824-1"},
+		{"%#h", x, "0-547-92824-6"},
+		{"% h", x, "978 0 547 92824 1"},
+		{"% #h", x, "0 547 92824 6"},
+		{"%+h", x, "ISBN 978-0-547-92824-1"},
+		{"%+#h", x, "ISBN 0-547-92824-6"},
+		{"%+n", x, "ISBN 9780547928241"},
+		{"[%20h]", x, "[   978-0-547-92824-1]"},
+		{"[%-20h]", x, "[978-0-547-92824-1   ]"},
+		{"%h", y, "979-10-12-34567-8"},
+		{"%#h", y, ""},
+		{"%+#h", y, ""},
+		{"%h", z, ""},
+		{"%+n", z, ""},
+		{"%v", z, ""},
+		{"%z", x, "%!z(isbn.ISBN=978-0-547-92824-1 (0-547-92824-6))"},
+	}
+	for _, c := range cases {
+		got := fmt.Sprintf(c.format, c.in)
+		if got != c.want {
+			t.Errorf("Sprintf(%q) == %q, want %q", c.format, got, c.want)
+		}
+	}
+
+	// The debugging verbs show the fields
+	got := fmt.Sprintf("%+v", x)
+	if !strings.Contains(got, "Registrant:547") {
+		t.Errorf("Sprintf(%q) == %q, want the fields", "%+v", got)
+	}
+
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// GeneratorOptions restrict the ISBNs that a Generator produces. The
+// zero value produces ISBN-13s from every registration group in the
+// range data.
+type GeneratorOptions struct {
+	Prefix string // only generate ISBNs with this EAN.UCC prefix
+	Group  string // only generate ISBNs in this registration group
+	Agency string // only generate ISBNs for this registration group agency
+	ISBN10 bool   // generate ISBN-10s (implies the 978 prefix)
+	Seed   int64  // the seed for the random number generator
+}
+
+// InvalidKind identifies the kind of invalid ISBN for a Generator to
+// produce.
+type InvalidKind int
+
+// The kinds of invalid ISBN that a Generator can produce.
+const (
+	// InvalidCheckDigit is for an ISBN with the wrong check digit.
+	InvalidCheckDigit InvalidKind = iota
+	// InvalidRegistrant is for an ISBN with a registrant element that
+	// is not in an allocated range (but a correct check digit).
+	InvalidRegistrant
+	// InvalidLength is for an ISBN that is one digit too short or too
+	// long.
+	InvalidLength
+	// InvalidCharacter is for an ISBN with a non-digit character.
+	InvalidCharacter
+)
+
+// maxAttempts is the number of attempts that a Generator makes at
+// finding an ISBN with an unallocated registrant.
+const maxAttempts = 10000
+
+// groupRef identifies a registration group in the range data.
+type groupRef struct {
+	prefix string
+	group  string
+	rs     registrant
+}
+
+// A Generator produces random ISBNs for use as test fixtures. The
+// ISBNs are drawn from the range data so that valid ISBNs are not just
+// correctly check-digited but also have allocated prefix, registration
+// group and registrant elements. For a given seed and range data a
+// Generator always produces the same sequence of ISBNs. A Generator is
+// not safe for concurrent use.
+type Generator struct {
+	r      *RangeData
+	rnd    *rand.Rand
+	isbn10 bool
+	groups []groupRef
+}
+
+// NewGenerator returns a Generator for the range data loaded by
+// LoadRangeData.
+func NewGenerator(opts GeneratorOptions) (*Generator, error) {
+	return defaultRangeData.NewGenerator(opts)
+}
+
+// NewGenerator returns a Generator for the range data in r. An error is
+// returned if no registration group matches the options.
+func (r *RangeData) NewGenerator(opts GeneratorOptions) (*Generator, error) {
+
+	t := r.rangeTable()
+	if len(t.rmd) == 0 {
+		return nil, ErrNoRangeData
+	}
+
+	// The groups are sorted so that the sequence of ISBNs only depends
+	// on the seed (and not on the map iteration order)
+	var groups []groupRef
+	for prefix, rgs := range t.rmd {
+		if opts.Prefix != "" && prefix != opts.Prefix {
+			continue
+		}
+		if opts.ISBN10 && prefix != p978 {
+			continue
+		}
+		for group, rs := range rgs {
+			if opts.Group != "" && group != opts.Group {
+				continue
+			}
+			if opts.Agency != "" && rs.Agency != opts.Agency {
+				continue
+			}
+			if len(rs.Ranges) == 0 {
+				continue
+			}
+			groups = append(groups, groupRef{prefix: prefix, group: group, rs: rs})
+		}
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("no registration groups match the generator options")
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].prefix != groups[j].prefix {
+			return groups[i].prefix < groups[j].prefix
+		}
+		return groups[i].group < groups[j].group
+	})
+
+	g := &Generator{
+		r:      r,
+		rnd:    rand.New(rand.NewSource(opts.Seed)),
+		isbn10: opts.ISBN10,
+		groups: groups,
+	}
+	return g, nil
+}
+
+// Valid returns a random, valid, ISBN (without hyphens).
+func (g *Generator) Valid() string {
+
+	for {
+		gr := g.groups[g.rnd.Intn(len(g.groups))]
+		rg := gr.rs.Ranges[g.rnd.Intn(len(gr.rs.Ranges))]
+		rStart := rg[0]
+		rEnd := rg[1]
+		rLen := rg[2]
+
+		width := elementDigits - len(gr.group) - rLen
+		if width < 1 {
+			continue
+		}
+
+		reg := rStart + g.rnd.Intn(rEnd-rStart+1)
+		pub := g.rnd.Intn(pow10(width))
+
+		body := fmt.Sprintf("%s%s%0*d%0*d", gr.prefix, gr.group, rLen, reg, width, pub)
+		return g.complete(body)
+	}
+}
+
+// Invalid returns a random ISBN (without hyphens) that is invalid in
+// the manner specified by kind. The ISBN is otherwise realistic (i.e.
+// apart from the deliberate error it is a valid ISBN). An error is
+// returned if no ISBN with an unallocated registrant could be found
+// (not every registration group has unallocated registrant ranges).
+func (g *Generator) Invalid(kind InvalidKind) (string, error) {
+
+	switch kind {
+	case InvalidCheckDigit:
+		s := []byte(g.Valid())
+		last := len(s) - 1
+		digits := "0123456789"
+		if g.isbn10 {
+			digits = "0123456789X"
+		}
+		for {
+			c := digits[g.rnd.Intn(len(digits))]
+			if c != s[last] {
+				s[last] = c
+				return string(s), nil
+			}
+		}
+
+	case InvalidRegistrant:
+		for i := 0; i < maxAttempts; i++ {
+			gr := g.groups[g.rnd.Intn(len(g.groups))]
+			width := elementDigits - len(gr.group)
+			body := fmt.Sprintf("%s%s%0*d", gr.prefix, gr.group, width, g.rnd.Intn(pow10(width)))
+			s := g.complete(body)
+
+			_, err := g.r.ParseISBN(s)
+			if errors.Is(err, ErrRegistrantNotAllocated) {
+				return s, nil
+			}
+		}
+		return "", errors.New("unable to generate an ISBN with an unallocated registrant")
+
+	case InvalidLength:
+		s := g.Valid()
+		if g.rnd.Intn(2) == 0 {
+			return s[:len(s)-1], nil
+		}
+		return s + fmt.Sprint(g.rnd.Intn(10)), nil
+
+	case InvalidCharacter:
+		s := []byte(g.Valid())
+		s[g.rnd.Intn(len(s)-1)] = byte('A' + g.rnd.Intn(26))
+		return string(s), nil
+	}
+
+	return "", fmt.Errorf("unknown invalid ISBN kind %d", kind)
+}
+
+// complete appends the check digit to the body (prefix, group,
+// registrant and publication elements) of an ISBN-13, converting the
+// result to an ISBN-10 if the generator produces ISBN-10s.
+func (g *Generator) complete(body string) string {
+	if g.isbn10 {
+		cd, _ := CalcCheckDigit10(body[3:] + "0")
+		return body[3:] + cd
+	}
+	cd, _ := CalcCheckDigit13(body + "0")
+	return body + cd
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGeneratorValid(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []GeneratorOptions{
+		{Seed: 1},
+		{Seed: 2, ISBN10: true},
+		{Seed: 3, Prefix: "979"},
+		{Seed: 4, Prefix: "978", Group: "88"},
+		{Seed: 5, Agency: "English language", ISBN10: true},
+	}
+	for _, opts := range cases {
+		g, err := NewGenerator(opts)
+		if err != nil {
+			t.Fatalf("NewGenerator(%+v) == fail, want success (%q)", opts, err)
+		}
+		for i := 0; i < 100; i++ {
+			s := g.Valid()
+			x, err := ParseISBN(s)
+			if err != nil {
+				t.Errorf("NewGenerator(%+v).Valid() == %q, want a valid ISBN (%q)", opts, s, err)
+				continue
+			}
+			if opts.ISBN10 && len(s) != 10 || !opts.ISBN10 && len(s) != 13 {
+				t.Errorf("NewGenerator(%+v).Valid() == %q, wrong length", opts, s)
+			}
+			if opts.Prefix != "" && x.Prefix != opts.Prefix {
+				t.Errorf("NewGenerator(%+v).Valid() == %q, wrong prefix", opts, s)
+			}
+			if opts.Group != "" && x.RegistrationGroup != opts.Group {
+				t.Errorf("NewGenerator(%+v).Valid() == %q, wrong group", opts, s)
+			}
+			if opts.Agency != "" && x.Agency != opts.Agency {
+				t.Errorf("NewGenerator(%+v).Valid() == %q, wrong agency", opts, s)
+			}
+		}
+	}
+
+	// The same seed gives the same sequence
+	g1, _ := NewGenerator(GeneratorOptions{Seed: 42})
+	g2, _ := NewGenerator(GeneratorOptions{Seed: 42})
+	for i := 0; i < 20; i++ {
+		s1 := g1.Valid()
+		s2 := g2.Valid()
+		if s1 != s2 {
+			t.Errorf("Generators with the same seed differ (%q != %q)", s1, s2)
+		}
+	}
+
+	_, err := NewGenerator(GeneratorOptions{Prefix: "979", ISBN10: true})
+	if err == nil {
+		t.Errorf("NewGenerator() for 979 ISBN-10s == success, want fail")
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestGeneratorInvalid(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		opts GeneratorOptions
+		kind InvalidKind
+		want error
+	}{
+		{GeneratorOptions{Seed: 1}, InvalidCheckDigit, ErrInvalidCheckDigit},
+		{GeneratorOptions{Seed: 1, ISBN10: true}, InvalidCheckDigit, ErrInvalidCheckDigit},
+		{GeneratorOptions{Seed: 1, Prefix: "979"}, InvalidRegistrant, ErrRegistrantNotAllocated},
+		{GeneratorOptions{Seed: 1}, InvalidLength, ErrInvalidLength},
+		{GeneratorOptions{Seed: 1, ISBN10: true}, InvalidCharacter, ErrInvalidCharacter},
+	}
+	for _, c := range cases {
+		g, err := NewGenerator(c.opts)
+		if err != nil {
+			t.Fatalf("NewGenerator(%+v) == fail, want success (%q)", c.opts, err)
+		}
+		for i := 0; i < 50; i++ {
+			s, err := g.Invalid(c.kind)
+			if err != nil {
+				t.Errorf("Generator.Invalid(%d) == fail, want success (%q)", c.kind, err)
+				continue
+			}
+			_, err = ParseISBN(s)
+			if !errors.Is(err, c.want) {
+				t.Errorf("ParseISBN(%q) == %v, want %v", s, err, c.want)
+			}
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
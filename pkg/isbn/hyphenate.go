@@ -0,0 +1,64 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+// Hyphenate13 returns the supplied ISBN (in either ISBN-10 or ISBN-13
+// form) as an ISBN-13 that is hyphenated according to the range data
+// loaded by LoadRangeData.
+func Hyphenate13(isbn string) (string, error) {
+	return defaultRangeData.Hyphenate13(isbn)
+}
+
+// Hyphenate13 returns the supplied ISBN (in either ISBN-10 or ISBN-13
+// form) as an ISBN-13 that is hyphenated according to the range data
+// in r.
+func (r *RangeData) Hyphenate13(isbn string) (string, error) {
+	x, err := r.ParseISBN(isbn)
+	if err != nil {
+		return "", err
+	}
+	return x.Hyphenated13()
+}
+
+// Hyphenate10 returns the supplied ISBN (in either ISBN-10 or ISBN-13
+// form) as an ISBN-10 that is hyphenated according to the range data
+// loaded by LoadRangeData.
+func Hyphenate10(isbn string) (string, error) {
+	return defaultRangeData.Hyphenate10(isbn)
+}
+
+// Hyphenate10 returns the supplied ISBN (in either ISBN-10 or ISBN-13
+// form) as an ISBN-10 that is hyphenated according to the range data
+// in r. ErrNoISBN10 is returned for ISBNs that do not have the 978
+// prefix.
+func (r *RangeData) Hyphenate10(isbn string) (string, error) {
+	x, err := r.ParseISBN(isbn)
+	if err != nil {
+		return "", err
+	}
+	return x.Hyphenated10()
+}
+
+// Hyphenated13 returns the ISBN as a hyphenated ISBN-13 (i.e.
+// 978-0-547-92824-1).
+func (x ISBN) Hyphenated13() (string, error) {
+	if !x.IsValid {
+		return "", ErrNotValid
+	}
+	return x.join(false, "-"), nil
+}
+
+// Hyphenated10 returns the ISBN as a hyphenated ISBN-10 (i.e.
+// 0-547-92824-6). ErrNoISBN10 is returned for ISBNs that do not have
+// the 978 prefix.
+func (x ISBN) Hyphenated10() (string, error) {
+	if !x.IsValid {
+		return "", ErrNotValid
+	}
+	if x.Prefix != p978 {
+		return "", ErrNoISBN10
+	}
+	return x.join(true, "-"), nil
+}
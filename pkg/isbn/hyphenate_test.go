@@ -0,0 +1,63 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHyphenate(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in     string
+		want13 string
+		want10 string
+		err10  error
+	}{
+		{"88 04 47328 2", "978-88-04-47328-2", "88-04-47328-2", nil},
+		{"978-8804473282", "978-88-04-47328-2", "88-04-47328-2", nil},
+		{"0547928246", "978-0-547-92824-1", "0-547-92824-6", nil},
+		{"97-80670-013951", "978-0-670-01395-1", "0-670-01395-1", nil},
+		{"089686281x", "978-0-89686-281-4", "0-89686-281-X", nil},
+		{"9780822527602", "978-0-8225-2760-2", "0-8225-2760-X", nil},
+		{"9791012345678", "979-10-12-34567-8", "", ErrNoISBN10},
+		{"9780590732053", "", "", ErrInvalidCheckDigit},
+		{"", "", "", ErrInvalidLength},
+	}
+	for _, c := range cases {
+		got13, err := Hyphenate13(c.in)
+		if got13 != c.want13 {
+			t.Errorf("Hyphenate13(%q) == %q, want %q (%v)", c.in, got13, c.want13, err)
+		}
+
+		got10, err := Hyphenate10(c.in)
+		if got10 != c.want10 {
+			t.Errorf("Hyphenate10(%q) == %q, want %q (%v)", c.in, got10, c.want10, err)
+		}
+		if c.err10 != nil && !errors.Is(err, c.err10) {
+			t.Errorf("Hyphenate10(%q) error == %v, want %v", c.in, err, c.err10)
+		}
+	}
+
+	// An unparsed ISBN can't be hyphenated
+	var x ISBN
+	_, err := x.Hyphenated13()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISBN{}.Hyphenated13() error == %v, want ErrNotValid", err)
+	}
+	_, err = x.Hyphenated10()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISBN{}.Hyphenated10() error == %v, want ErrNotValid", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
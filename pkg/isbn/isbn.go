@@ -19,7 +19,10 @@ import (
 	"strings"
 )
 
-const p978 = "978"
+const (
+	p978 = "978"
+	p979 = "979"
+)
 
 // An ISBN contains the elements of a parsed ISBN. The elements
 // consisting of:
@@ -44,39 +47,27 @@ type ISBN struct {
 // ISBN to parse may, or may not, have spaces and/or hyphens we ensure
 // that there are none in order to simplify the parsing.
 func stripISBN(isbn string) string {
-	r, _ := regexp.Compile(`[\s-]`)
-	return r.ReplaceAllString(strings.ToUpper(isbn), "")
+	return stripRE.ReplaceAllString(strings.ToUpper(isbn), "")
 }
 
+// stripRE matches the spaces and hyphens removed by stripISBN.
+var stripRE = regexp.MustCompile(`[\s-]`)
+
 // chkLength checks that the length of the ISBN is correct
 func chkLength(isbn string) error {
 	if len(isbn) != 10 && len(isbn) != 13 {
-		return errors.New("ISBN length is incorrect")
+		return newParseError(StageLength, isbn, -1, ErrInvalidLength)
 	}
 	return nil
 }
 
 // chkCharacters checks that the characters in the ISBN are all valid.
 func chkCharacters(isbn string) error {
-	mainDigits := isbn[:len(isbn)-1]
-	checkDigit := isbn[len(isbn)-1:]
-
-	b := []byte(mainDigits)
-	matched, err := regexp.Match("[^0-9]", b)
-	if err != nil {
-		return err
-	} else if matched {
-		return errors.New("Invalid character found in ISBN")
-	}
-
-	b = []byte(checkDigit)
-	matched, err = regexp.Match("[^0-9X]", b)
-	if err != nil {
-		return err
-	} else if matched {
-		return errors.New("invalid character found in check digit")
+	// X is only valid as the check digit
+	i := invalidChar(isbn)
+	if i >= 0 {
+		return newParseError(StageCharacters, isbn, i, ErrInvalidCharacter)
 	}
-
 	return nil
 }
 
@@ -100,7 +91,7 @@ func chkCheckDigit(isbn string) (bool, error) {
 	if err != nil {
 		return false, err
 	} else if testDigit != checkDigit {
-		return false, errors.New("ISBN check digit is incorrect")
+		return false, newParseError(StageCheckDigit, isbn, len(isbn)-1, ErrInvalidCheckDigit)
 	}
 
 	return true, nil
@@ -109,6 +100,12 @@ func chkCheckDigit(isbn string) (bool, error) {
 // CalcCheckDigit calculates the check digit for the ISBN.
 func CalcCheckDigit(isbn string) (string, error) {
 
+	var buf [13]byte
+	n, ok := normalize(&buf, isbn)
+	if ok && (n == 10 || n == 13) && invalidChar(buf[:n]) < 0 {
+		return checkDigitString(checkDigit(buf[:n])), nil
+	}
+
 	isbn = stripISBN(isbn)
 
 	err := chkLength(isbn)
@@ -127,108 +124,81 @@ func CalcCheckDigit(isbn string) (string, error) {
 	return CalcCheckDigit13(isbn)
 }
 
-// CalcCheckDigit10 calculates the check digit for an ISBN-10.
+// CalcCheckDigit10 calculates the check digit for an ISBN-10 (from the
+// first nine digits of isbn).
 func CalcCheckDigit10(isbn string) (string, error) {
-
-	var cd string
-
-	var sum int32
-	var i int32
-	for i = 0; i < 9; i++ {
-		v1, err := strconv.Atoi(string(isbn[i]))
-		if err != nil {
-			return cd, err
-		}
-		sum += (10 - i) * int32(v1)
-	}
-	rem := (11 - sum%11) % 11
-	if rem == 10 {
-		cd = "X"
-	} else {
-		cd = strconv.Itoa(int(rem))
+	err := chkDigits(isbn, 9)
+	if err != nil {
+		return "", err
 	}
-
-	return cd, nil
+	return checkDigitString(checkDigit10(isbn)), nil
 }
 
-// CalcCheckDigit13 calculates the check digit for an ISBN-13.
+// CalcCheckDigit13 calculates the check digit for an ISBN-13 (from the
+// first twelve digits of isbn).
 func CalcCheckDigit13(isbn string) (string, error) {
+	err := chkDigits(isbn, 12)
+	if err != nil {
+		return "", err
+	}
+	return checkDigitString(checkDigit13(isbn)), nil
+}
 
-	var cd string
-	var sum int32
-	var i int32
-	for i = 0; i < 12; i += 2 {
-		v1, err := strconv.Atoi(string(isbn[i]))
-		if err != nil {
-			return cd, err
-		}
-		v2, err := strconv.Atoi(string(isbn[i+1]))
-		if err != nil {
-			return cd, err
+// chkDigits checks that the first n characters of the ISBN are digits.
+func chkDigits(isbn string, n int) error {
+	if len(isbn) < n {
+		return newParseError(StageLength, isbn, -1, ErrInvalidLength)
+	}
+	for i := 0; i < n; i++ {
+		if isbn[i] < '0' || isbn[i] > '9' {
+			return newParseError(StageCharacters, isbn, i, ErrInvalidCharacter)
 		}
-		sum += int32(v1) + 3*int32(v2)
-
 	}
-	rem := (10 - sum%10) % 10
-	cd = strconv.Itoa(int(rem))
-
-	return cd, nil
+	return nil
 }
 
 // ValidateCheckDigit test whether or not the check digit for an ISBN
 // matches the calculated check digit.
 func ValidateCheckDigit(isbn string) bool {
-
-	isbn = stripISBN(isbn)
-	err := chkLength(isbn)
-	if err != nil {
-		return false
-	}
-
-	provided := isbn[len(isbn)-1:]
-
-	calculated, _ := CalcCheckDigit(isbn)
-
-	return provided == calculated
+	var buf [13]byte
+	_, ok := quickCheck(&buf, isbn)
+	return ok
 }
 
 // ParseISBN parses the supplied ISBN into its constituent elements and
-// checks the validity of the elements.
+// checks the validity of the elements using the range data loaded by
+// LoadRangeData.
 func ParseISBN(isbn string) (ISBN, error) {
+	return defaultRangeData.ParseISBN(isbn)
+}
 
-	var ret ISBN
-
-	isbn = stripISBN(isbn)
-
-	// Ensure that the basic format (length, characters) of the ISBN
-	// match the specification.
-	err := chkLength(isbn)
-	if err != nil {
-		return ret, err
-	}
+// ParseISBN parses the supplied ISBN into its constituent elements and
+// checks the validity of the elements using the range data in r.
+func (r *RangeData) ParseISBN(isbn string) (ISBN, error) {
 
-	err = chkCharacters(isbn)
-	if err != nil {
-		return ret, err
-	}
+	var ret ISBN
 
-	_, err = chkCheckDigit(isbn)
-	if err != nil {
-		return ret, err
+	// Ensure that the basic format (length, characters, check digit)
+	// of the ISBN match the specification.
+	var buf [13]byte
+	n, ok := quickCheck(&buf, isbn)
+	if !ok {
+		return ret, validationError(isbn)
 	}
+	isbn = string(buf[:n])
 
 	// Ensure that the range data has been loaded so that the ISBN can
 	// be parsed and that the remainder of the validation can be
 	// performed.
-	if !HasRangeData() {
-		err = errors.New("no range data for parsing ISBNs (perhaps you did not LoadRangeData)")
+	t := r.rangeTable()
+	if len(t.rmd) == 0 {
+		err := newParseError(StageRangeData, isbn, -1, ErrNoRangeData)
 		return ret, err
 	}
 
-	// Since the different elements of an ISBN are of variable length it
-	// is necessary to start peeling one character at a time from the
-	// left hand side of the ISBN until the next needed element can be
-	// matched (or the end of the ISBN is reached without a match).
+	// Since the different elements of an ISBN are of variable length
+	// the length of each element is determined, by the range data
+	// rules, from the seven digits that follow the previous element.
 	// https://en.wikipedia.org/wiki/Isbn has a reasonable writeup on
 	// the subject.
 	//
@@ -250,54 +220,58 @@ func ParseISBN(isbn string) (ISBN, error) {
 	//       01395-   | Publication (Llama Llama and the Bully Goat)
 	//       1        | Check digit
 
+	// The EAN.UCC prefix is always the first three digits (ISBN-10s
+	// being 978 prefixed) and the EAN.UCC prefix rules determine the
+	// length of the registration group from the seven digits that
+	// follow the prefix.
+	body := []byte(isbn[:len(isbn)-1])
 	if len(isbn) == 10 {
-		ret.Prefix = p978
+		body = append([]byte(p978), body...)
 	}
 
-	rg := []byte(isbn[:len(isbn)-1])
-	var pfx []byte
-	var grp []byte
-	var reg []byte
-	var pub []byte
+	// shift converts positions in body to positions in isbn (for
+	// reporting where an element was rejected)
+	shift := len(body) + 1 - len(isbn)
 
-	var rs registrant
+	ret.Prefix = string(body[:3])
+	_, ok = t.ean[ret.Prefix]
+	if !ok {
+		err := newParseError(StagePrefix, isbn, 0, ErrUnknownPrefix)
+		return ISBN{}, err
+	}
 
-	for _, digit := range rg {
+	// 979-0 is never an ISBN (whatever the range data says) as it is
+	// the prefix of ISMNs
+	if ret.Prefix+string(body[3]) == pISMN {
+		err := newParseError(StageGroup, isbn, 3-shift, ErrISMN)
+		return ISBN{}, err
+	}
 
-		if ret.Prefix == "" {
-			pfx = append(pfx, digit)
-			_, ok := rmd[string(pfx[:])]
-			if ok {
-				ret.Prefix = string(pfx[:])
-			}
-		} else if ret.RegistrationGroup == "" {
-			grp = append(grp, digit)
-			_, ok := rmd[ret.Prefix][string(grp[:])]
-			if ok {
-				ret.RegistrationGroup = string(grp[:])
-				rs = rmd[ret.Prefix][string(grp[:])]
-				ret.Agency = rs.Agency
-			}
-		} else if ret.Registrant == "" {
-			reg = append(reg, digit)
-			chk, err := toInt(reg[:])
-			if err != nil {
-				return ret, err
-			}
+	gLen := t.groupLength(ret.Prefix, body[3:10])
+	if gLen == 0 {
+		err := newParseError(StageGroup, isbn, 3-shift, ErrUnknownGroup)
+		return ISBN{}, err
+	}
 
-			for _, rg := range rs.Ranges {
-				rStart := rg[0]
-				rEnd := rg[1]
-				rLen := rg[2]
-				if len(reg) == rLen && chk >= rStart && chk <= rEnd {
-					ret.Registrant = string(reg[:])
-				}
-			}
-		} else {
-			pub = append(pub, digit)
-			ret.Publication = string(pub[:])
-		}
+	ret.RegistrationGroup = string(body[3 : 3+gLen])
+	rs, ok := t.rmd[ret.Prefix][ret.RegistrationGroup]
+	if !ok {
+		err := newParseError(StageGroup, isbn, 3-shift, ErrUnknownGroup)
+		return ISBN{}, err
+	}
+	ret.Agency = rs.Agency
+
+	// The registrant rules are indexed by the seven digits following
+	// the registration group. The registrant must leave at least one
+	// digit for the publication element.
+	rest := body[3+gLen:]
+	rLen, ok := rs.index.lookup(sevenDigits(rest))
+	if !ok || rLen >= len(rest) {
+		err := newParseError(StageRegistrant, isbn, 3+gLen-shift, ErrRegistrantNotAllocated)
+		return ISBN{}, err
 	}
+	ret.Registrant = string(rest[:rLen])
+	ret.Publication = string(rest[rLen:])
 
 	// Check the check digit
 	if len(isbn) == 10 {
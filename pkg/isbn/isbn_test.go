@@ -5,8 +5,8 @@
 package isbn
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"testing"
 )
 
@@ -45,10 +45,7 @@ func TestISBN02loadrangedata(t *testing.T) {
 
 	// Ensure that the range data is loaded
 	if !HasRangeData() {
-		xmlFile := os.Getenv("ISBN_RANGE_FILE")
-		if xmlFile == "" {
-			t.Errorf("ISBN_RANGE_FILE Env variable not set")
-		}
+		xmlFile := rangeFile()
 
 		want := true
 		got, err := LoadRangeData(xmlFile)
@@ -266,14 +263,111 @@ func TestISBN07conversion(t *testing.T) {
 	_, _ = UnloadRangeData()
 }
 
+func TestISBN08eanucc(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	// Test that the EAN.UCC prefix rules are used for identifying the
+	// prefix and registration group
+	cases := []struct {
+		in    string
+		want  bool
+		group string
+	}{
+		{"88 04 47328 2", true, "88"},
+		{"978-0547928241", true, "0"},
+		{"978-8891230195", true, "88"},
+		{"979-10-12-34567-8", true, "10"},
+		{"9771234567003", false, ""}, // not an ISBN prefix
+	}
+	for _, c := range cases {
+		isbn, err := ParseISBN(c.in)
+		if err != nil && c.want {
+			t.Errorf("ParseISBN(%q) == fail, want success (%q)", c.in, err)
+		} else if err == nil && !c.want {
+			t.Errorf("ParseISBN(%q) == success, want fail", c.in)
+		} else if isbn.RegistrationGroup != c.group {
+			t.Errorf("ParseISBN(%q).RegistrationGroup == %q, want %q", c.in, isbn.RegistrationGroup, c.group)
+		}
+	}
+
+	// Groups that are unallocated by the prefix rules, or are allocated
+	// but not in the range data
+	var rd RangeData
+	_, err := rd.LoadRangeDataBytes([]byte(minimalXML))
+	if err != nil {
+		t.Fatalf("RangeData.LoadRangeDataBytes() failed (%q)", err)
+	}
+	for _, in := range []string{
+		"9789512345670", // unallocated 978 range
+		"9795123456780", // unallocated 979 range
+		"9789112345674", // allocated range, group not in data
+	} {
+		_, err = rd.ParseISBN(in)
+		if !errors.Is(err, ErrUnknownGroup) {
+			t.Errorf("RangeData.ParseISBN(%q) == %v, want ErrUnknownGroup", in, err)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestISBN09registrant(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	// Test that ISBNs with unallocated registrant elements are rejected
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{"979-12-200-1234-8", true},
+		{"979-8-3500-1234-7", true},
+	}
+	for _, c := range cases {
+		isbn, err := ParseISBN(c.in)
+		if err != nil && c.want {
+			t.Errorf("ParseISBN(%q) == fail, want success (%q)", c.in, err)
+		} else if err == nil && !c.want {
+			t.Errorf("ParseISBN(%q) == success (%s), want fail", c.in, isbn)
+		} else if err != nil && !errors.Is(err, ErrRegistrantNotAllocated) {
+			t.Errorf("ParseISBN(%q) == %q, want ErrRegistrantNotAllocated", c.in, err)
+		}
+	}
+
+	// Unused (Length 0) registrant ranges
+	var rd RangeData
+	_, err := rd.LoadRangeDataBytes([]byte(minimalXML))
+	if err != nil {
+		t.Fatalf("RangeData.LoadRangeDataBytes() failed (%q)", err)
+	}
+	for _, in := range []string{"9789021234564", "9791021234567"} {
+		isbn, err := rd.ParseISBN(in)
+		if !errors.Is(err, ErrRegistrantNotAllocated) {
+			t.Errorf("RangeData.ParseISBN(%q) == %s (%v), want ErrRegistrantNotAllocated", in, isbn, err)
+		}
+	}
+	_, err = rd.ParseISBN("9791012123450")
+	if err != nil {
+		t.Errorf("RangeData.ParseISBN(%q) == fail, want success (%q)", "9791012123450", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
+
 func prepRangeData() bool {
 
 	// Ensure that the range data is loaded
 	if !HasRangeData() {
-		xmlFile := os.Getenv("ISBN_RANGE_FILE")
-		if xmlFile == "" {
-			return false
-		}
+		xmlFile := rangeFile()
 		want := true
 		got, err := LoadRangeData(xmlFile)
 		if err != nil {
@@ -284,3 +378,98 @@ func prepRangeData() bool {
 	}
 	return true
 }
+
+func TestISBN10normalize(t *testing.T) {
+
+	// Test that the single pass normalisation matches stripISBN
+	cases := []string{
+		"88 04 47328 2",
+		"978-8804473282",
+		"089686281x",
+		"\t978-0-547-92824-1\r\n",
+		"9780590d32053",
+		"9780590132053F",
+		"978-059013205f-",
+		"97805901320531234",
+		"",
+		"  - ",
+	}
+	for _, c := range cases {
+		var buf [13]byte
+		n, ok := normalize(&buf, c)
+		want := stripISBN(c)
+		if ok && string(buf[:n]) != want {
+			t.Errorf("normalize(%q) == %q, want %q", c, buf[:n], want)
+		} else if !ok && len(want) <= len(buf) {
+			t.Errorf("normalize(%q) == fail, want %q", c, want)
+		}
+	}
+}
+
+func TestISBN11allocs(t *testing.T) {
+
+	// The check digit validation should not allocate
+	cases := []string{"978-0547928241", "089686281x", "9780590732053", "9780590d32053"}
+	for _, c := range cases {
+		got := testing.AllocsPerRun(100, func() {
+			_ = ValidateCheckDigit(c)
+		})
+		if got != 0 {
+			t.Errorf("ValidateCheckDigit(%q) == %v allocations, want 0", c, got)
+		}
+		got = testing.AllocsPerRun(100, func() {
+			_, _ = CalcCheckDigit(c)
+		})
+
+		// Only ISBNs with 10 or 13 valid characters (whether or not
+		// the check digit is right) take the allocation free path
+		var buf [13]byte
+		n, ok := normalize(&buf, c)
+		fast := ok && (n == 10 || n == 13) && invalidChar(buf[:n]) < 0
+		if got != 0 && fast {
+			t.Errorf("CalcCheckDigit(%q) == %v allocations, want 0", c, got)
+		}
+	}
+}
+
+func BenchmarkStripISBN(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = stripISBN("978-0-547-92824-1")
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	b.ReportAllocs()
+	var buf [13]byte
+	for i := 0; i < b.N; i++ {
+		_, _ = normalize(&buf, "978-0-547-92824-1")
+	}
+}
+
+func BenchmarkValidateCheckDigit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateCheckDigit("978-0-547-92824-1")
+	}
+}
+
+func BenchmarkCalcCheckDigit13(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CalcCheckDigit13("9780547928241")
+	}
+}
+
+func BenchmarkParseISBN(b *testing.B) {
+	if !prepRangeData() {
+		b.Fatalf("prepRangeData failed")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseISBN("978-0-547-92824-1")
+	}
+	b.StopTimer()
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"strings"
+)
+
+// pISMN is the EAN.UCC prefix (and registration group) of ISMN-13s. It
+// is replaced by "M" in the (legacy) ISMN-10 form.
+const pISMN = "9790"
+
+// An ISMN contains the elements of a parsed International Standard
+// Music Number. The elements consisting of:
+//
+//	[979-0 (or M)]-
+//	[Publisher element]-
+//	[Item element]-
+//	[Check-digit]
+//
+// The check digit is the same for both the 13-digit (979-0-2600-0043-8)
+// and the legacy 10-character (M-2600-0043-8) form as the M is counted
+// as the digits 9790 when calculating it.
+type ISMN struct {
+	Publisher  string
+	Item       string
+	CheckDigit string
+	IsValid    bool
+}
+
+// ismnPublisherLength returns the length of the publisher element of
+// an ISMN from the first digit of it. Unlike for ISBNs the ranges are
+// fixed by the International ISMN Agency rather than being published
+// in the range data.
+func ismnPublisherLength(c byte) int {
+	switch {
+	case c == '0':
+		return 3 // 000 - 099
+	case c <= '3':
+		return 4 // 1000 - 3999
+	case c <= '6':
+		return 5 // 40000 - 69999
+	case c <= '8':
+		return 6 // 700000 - 899999
+	}
+	return 7 // 9000000 - 9999999
+}
+
+// ParseISMN parses the supplied ISMN, in either the 13-digit form
+// (9790260000438) or the legacy 10-character form (M260000438), into
+// its constituent elements. As for ISBNs, spaces and hyphens are
+// ignored.
+//
+// The errors are *ParseErrors as for ParseISBN. A 13-digit number that
+// does not start with 979-0 (such as an ISBN) is rejected with
+// ErrUnknownPrefix.
+func ParseISMN(ismn string) (ISMN, error) {
+
+	var ret ISMN
+
+	ismn = stripISBN(ismn)
+
+	err := chkLength(ismn)
+	if err != nil {
+		return ret, err
+	}
+
+	// Convert to the 13-digit form for checking
+	digits := ismn
+	if len(ismn) == 10 {
+		if ismn[0] != 'M' {
+			return ret, newParseError(StagePrefix, ismn, 0, ErrUnknownPrefix)
+		}
+		digits = pISMN + ismn[1:]
+	}
+
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			pos := i - (len(digits) - len(ismn))
+			return ret, newParseError(StageCharacters, ismn, pos, ErrInvalidCharacter)
+		}
+	}
+
+	if digits[:len(pISMN)] != pISMN {
+		return ret, newParseError(StagePrefix, ismn, 0, ErrUnknownPrefix)
+	}
+
+	if checkDigit13(digits) != digits[12] {
+		return ret, newParseError(StageCheckDigit, ismn, len(ismn)-1, ErrInvalidCheckDigit)
+	}
+
+	rest := digits[len(pISMN):12]
+	pLen := ismnPublisherLength(rest[0])
+	ret.Publisher = rest[:pLen]
+	ret.Item = rest[pLen:]
+	ret.CheckDigit = digits[12:]
+	ret.IsValid = true
+
+	return ret, nil
+}
+
+// ValidateISMN tests whether or not the ISMN (in either form) is valid.
+func ValidateISMN(ismn string) bool {
+	_, err := ParseISMN(ismn)
+	return err == nil
+}
+
+// ISMN13 returns the ISMN in the 13-digit form (9790260000438).
+func (m ISMN) ISMN13() string {
+	if !m.IsValid {
+		return ""
+	}
+	return pISMN + m.Publisher + m.Item + m.CheckDigit
+}
+
+// ISMN10 returns the ISMN in the legacy 10-character form (M260000438).
+func (m ISMN) ISMN10() string {
+	if !m.IsValid {
+		return ""
+	}
+	return "M" + m.Publisher + m.Item + m.CheckDigit
+}
+
+// Hyphenated13 returns the ISMN as a hyphenated 13-digit ISMN (i.e.
+// 979-0-2600-0043-8).
+func (m ISMN) Hyphenated13() (string, error) {
+	if !m.IsValid {
+		return "", ErrNotValid
+	}
+	return strings.Join([]string{pISMN[:3], pISMN[3:], m.Publisher, m.Item, m.CheckDigit}, "-"), nil
+}
+
+// Hyphenated10 returns the ISMN as a hyphenated legacy 10-character
+// ISMN (i.e. M-2600-0043-8).
+func (m ISMN) Hyphenated10() (string, error) {
+	if !m.IsValid {
+		return "", ErrNotValid
+	}
+	return strings.Join([]string{"M", m.Publisher, m.Item, m.CheckDigit}, "-"), nil
+}
+
+// String implements the Stringer interface.
+func (m ISMN) String() string {
+	s, err := m.Hyphenated13()
+	if err != nil {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseISMN(t *testing.T) {
+
+	cases := []struct {
+		in     string
+		want13 string
+		want10 string
+		hyph13 string
+		hyph10 string
+	}{
+		{"979-0-2600-0043-8", "9790260000438", "M260000438", "979-0-2600-0043-8", "M-2600-0043-8"},
+		{"M-2600-0043-8", "9790260000438", "M260000438", "979-0-2600-0043-8", "M-2600-0043-8"},
+		{"m 060 11561 5", "9790060115615", "M060115615", "979-0-060-11561-5", "M-060-11561-5"},
+		{"9790500123453", "9790500123453", "M500123453", "979-0-50012-345-3", "M-50012-345-3"},
+		{"9790701234569", "9790701234569", "M701234569", "979-0-701234-56-9", "M-701234-56-9"},
+		{"M901679177", "9790901679177", "M901679177", "979-0-9016791-7-7", "M-9016791-7-7"},
+	}
+	for _, c := range cases {
+		m, err := ParseISMN(c.in)
+		if err != nil {
+			t.Errorf("ParseISMN(%q) error == %v, want nil", c.in, err)
+			continue
+		}
+		if m.ISMN13() != c.want13 {
+			t.Errorf("ParseISMN(%q).ISMN13() == %q, want %q", c.in, m.ISMN13(), c.want13)
+		}
+		if m.ISMN10() != c.want10 {
+			t.Errorf("ParseISMN(%q).ISMN10() == %q, want %q", c.in, m.ISMN10(), c.want10)
+		}
+		got, _ := m.Hyphenated13()
+		if got != c.hyph13 {
+			t.Errorf("ParseISMN(%q).Hyphenated13() == %q, want %q", c.in, got, c.hyph13)
+		}
+		got, _ = m.Hyphenated10()
+		if got != c.hyph10 {
+			t.Errorf("ParseISMN(%q).Hyphenated10() == %q, want %q", c.in, got, c.hyph10)
+		}
+		if m.String() != c.hyph13 {
+			t.Errorf("ParseISMN(%q).String() == %q, want %q", c.in, m.String(), c.hyph13)
+		}
+		if !ValidateISMN(c.in) {
+			t.Errorf("ValidateISMN(%q) == false, want true", c.in)
+		}
+	}
+
+	failures := []struct {
+		in   string
+		want error
+		pos  int
+	}{
+		{"979-0-2600-0043-9", ErrInvalidCheckDigit, 12},
+		{"M-2600-0043-9", ErrInvalidCheckDigit, 9},
+		{"979-0-2600-004", ErrInvalidLength, -1},
+		{"9780547928241", ErrUnknownPrefix, 0},
+		{"0547928246", ErrUnknownPrefix, 0},
+		{"M-2600-0X43-8", ErrInvalidCharacter, 6},
+		{"979026000043X", ErrInvalidCharacter, 12},
+	}
+	for _, c := range failures {
+		_, err := ParseISMN(c.in)
+		if !errors.Is(err, c.want) {
+			t.Errorf("ParseISMN(%q) error == %v, want %v", c.in, err, c.want)
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Pos != c.pos {
+			t.Errorf("ParseISMN(%q) error == %#v, want position %d", c.in, err, c.pos)
+		}
+		if ValidateISMN(c.in) {
+			t.Errorf("ValidateISMN(%q) == true, want false", c.in)
+		}
+	}
+
+	// An unparsed ISMN can't be formatted
+	var m ISMN
+	_, err := m.Hyphenated13()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISMN{}.Hyphenated13() error == %v, want ErrNotValid", err)
+	}
+	if m.ISMN13() != "" || m.ISMN10() != "" || m.String() != "" {
+		t.Errorf("ISMN{} formats as %q, want \"\"", m.ISMN13())
+	}
+}
+
+func TestParseISBNRejectsISMN(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	// A valid ISMN is never a valid ISBN
+	for _, in := range []string{"9790260000438", "979-0-060-11561-5"} {
+		_, err := ParseISBN(in)
+		if !errors.Is(err, ErrISMN) {
+			t.Errorf("ParseISBN(%q) error == %v, want ErrISMN", in, err)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
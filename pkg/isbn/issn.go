@@ -0,0 +1,146 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+// pISSN is the EAN.UCC prefix of the EAN-13 form of ISSNs.
+const pISSN = "977"
+
+// An ISSN contains the elements of a parsed International Standard
+// Serial Number (i.e. 0317-8471). When the ISSN is converted to an
+// EAN-13 (977-0317847-00-1) the check digit is dropped and two variant
+// digits (usually 00, but sometimes used for the issue or price) are
+// added before the EAN-13 check digit.
+type ISSN struct {
+	Number     string // the seven digits of the ISSN before the check digit
+	CheckDigit string
+	Variant    string // the EAN-13 variant digits ("00" if not set)
+	IsValid    bool
+}
+
+// CalcISSNCheckDigit calculates the check digit for an ISSN (from the
+// first seven digits of issn). As for ISBN-10s the check digit is
+// modulus 11 with X representing 10.
+func CalcISSNCheckDigit(issn string) (string, error) {
+	err := chkDigits(issn, 7)
+	if err != nil {
+		return "", err
+	}
+	return checkDigitString(checkDigitISSN(issn)), nil
+}
+
+// checkDigitISSN returns the ISSN check digit for the first seven
+// digits of s. The digits must have been checked.
+func checkDigitISSN(s string) byte {
+	var sum int
+	for i := 0; i < 7; i++ {
+		sum += (8 - i) * int(s[i]-'0')
+	}
+	rem := (11 - sum%11) % 11
+	if rem == 10 {
+		return 'X'
+	}
+	return byte('0' + rem)
+}
+
+// ParseISSN parses the supplied ISSN, either as an ISSN (0317-8471) or
+// as the EAN-13 form of an ISSN (9770317847001), into its constituent
+// elements. As for ISBNs, spaces and hyphens are ignored.
+//
+// The errors are *ParseErrors as for ParseISBN. A 13-digit number that
+// does not start with 977 (such as an ISBN) is rejected with
+// ErrUnknownPrefix.
+func ParseISSN(issn string) (ISSN, error) {
+
+	var ret ISSN
+
+	issn = stripISBN(issn)
+
+	if len(issn) != 8 && len(issn) != 13 {
+		return ret, newParseError(StageLength, issn, -1, ErrInvalidLength)
+	}
+
+	if len(issn) == 13 {
+		err := chkDigits(issn, 13)
+		if err != nil {
+			return ret, err
+		}
+		if checkDigit13(issn) != issn[12] {
+			return ret, newParseError(StageCheckDigit, issn, 12, ErrInvalidCheckDigit)
+		}
+		if issn[:3] != pISSN {
+			return ret, newParseError(StagePrefix, issn, 0, ErrUnknownPrefix)
+		}
+
+		ret.Number = issn[3:10]
+		ret.CheckDigit = checkDigitString(checkDigitISSN(ret.Number))
+		ret.Variant = issn[10:12]
+		ret.IsValid = true
+		return ret, nil
+	}
+
+	i := invalidChar(issn)
+	if i >= 0 {
+		return ret, newParseError(StageCharacters, issn, i, ErrInvalidCharacter)
+	}
+	if checkDigitISSN(issn) != issn[7] {
+		return ret, newParseError(StageCheckDigit, issn, 7, ErrInvalidCheckDigit)
+	}
+
+	ret.Number = issn[:7]
+	ret.CheckDigit = issn[7:]
+	ret.Variant = "00"
+	ret.IsValid = true
+	return ret, nil
+}
+
+// ValidateISSN tests whether or not the ISSN (in either form) is valid.
+func ValidateISSN(issn string) bool {
+	_, err := ParseISSN(issn)
+	return err == nil
+}
+
+// ISSN returns the ISSN in compact form (03178471).
+func (s ISSN) ISSN() string {
+	if !s.IsValid {
+		return ""
+	}
+	return s.Number + s.CheckDigit
+}
+
+// Hyphenated returns the ISSN in its usual hyphenated form (i.e.
+// 0317-8471).
+func (s ISSN) Hyphenated() (string, error) {
+	if !s.IsValid {
+		return "", ErrNotValid
+	}
+	return s.Number[:4] + "-" + s.Number[4:] + s.CheckDigit, nil
+}
+
+// EAN13 returns the ISSN as an EAN-13 (9770317847001) using the
+// variant digits of the ISSN (00 if they are not set). An ISSN with
+// variant digits that are not two digits has no EAN-13 form.
+func (s ISSN) EAN13() string {
+	if !s.IsValid {
+		return ""
+	}
+	v := s.Variant
+	if v == "" {
+		v = "00"
+	}
+	if len(v) != 2 || chkDigits(v, 2) != nil {
+		return ""
+	}
+	ean := pISSN + s.Number + v
+	return ean + checkDigitString(checkDigit13(ean+"0"))
+}
+
+// String implements the Stringer interface.
+func (s ISSN) String() string {
+	out, err := s.Hyphenated()
+	if err != nil {
+		return ""
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalcISSNCheckDigit(t *testing.T) {
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"0317847", "1"},
+		{"00280836", "6"},
+		{"1050124", "X"},
+		{"2434561", "X"},
+	}
+	for _, c := range cases {
+		got, err := CalcISSNCheckDigit(c.in)
+		if got != c.want || err != nil {
+			t.Errorf("CalcISSNCheckDigit(%q) == %q, %v, want %q", c.in, got, err, c.want)
+		}
+	}
+
+	_, err := CalcISSNCheckDigit("03178")
+	if !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("CalcISSNCheckDigit(\"03178\") error == %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestParseISSN(t *testing.T) {
+
+	cases := []struct {
+		in      string
+		issn    string
+		hyph    string
+		variant string
+		ean     string
+	}{
+		{"0317-8471", "03178471", "0317-8471", "00", "9770317847001"},
+		{"0028 0836", "00280836", "0028-0836", "00", "9770028083002"},
+		{"1050-124x", "1050124X", "1050-124X", "00", "9771050124008"},
+		{"977-0317847-00-1", "03178471", "0317-8471", "00", "9770317847001"},
+		{"9770317847155", "03178471", "0317-8471", "15", "9770317847155"},
+		{"9772434561150", "2434561X", "2434-561X", "15", "9772434561150"},
+	}
+	for _, c := range cases {
+		s, err := ParseISSN(c.in)
+		if err != nil {
+			t.Errorf("ParseISSN(%q) error == %v, want nil", c.in, err)
+			continue
+		}
+		if s.ISSN() != c.issn {
+			t.Errorf("ParseISSN(%q).ISSN() == %q, want %q", c.in, s.ISSN(), c.issn)
+		}
+		got, _ := s.Hyphenated()
+		if got != c.hyph || s.String() != c.hyph {
+			t.Errorf("ParseISSN(%q).Hyphenated() == %q, want %q", c.in, got, c.hyph)
+		}
+		if s.Variant != c.variant {
+			t.Errorf("ParseISSN(%q).Variant == %q, want %q", c.in, s.Variant, c.variant)
+		}
+		if s.EAN13() != c.ean {
+			t.Errorf("ParseISSN(%q).EAN13() == %q, want %q", c.in, s.EAN13(), c.ean)
+		}
+		if !ValidateISSN(c.in) {
+			t.Errorf("ValidateISSN(%q) == false, want true", c.in)
+		}
+	}
+
+	failures := []struct {
+		in   string
+		want error
+		pos  int
+	}{
+		{"0317-8472", ErrInvalidCheckDigit, 7},
+		{"9770317847002", ErrInvalidCheckDigit, 12},
+		{"0317-847", ErrInvalidLength, -1},
+		{"03X7-8471", ErrInvalidCharacter, 2},
+		{"977031784700X", ErrInvalidCharacter, 12},
+		{"9780547928241", ErrUnknownPrefix, 0},
+	}
+	for _, c := range failures {
+		_, err := ParseISSN(c.in)
+		if !errors.Is(err, c.want) {
+			t.Errorf("ParseISSN(%q) error == %v, want %v", c.in, err, c.want)
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Pos != c.pos {
+			t.Errorf("ParseISSN(%q) error == %#v, want position %d", c.in, err, c.pos)
+		}
+		if ValidateISSN(c.in) {
+			t.Errorf("ValidateISSN(%q) == true, want false", c.in)
+		}
+	}
+
+	// The variant digits may be changed
+	s, _ := ParseISSN("0317-8471")
+	s.Variant = "15"
+	if s.EAN13() != "9770317847155" {
+		t.Errorf("ISSN.EAN13() with variant 15 == %q, want %q", s.EAN13(), "9770317847155")
+	}
+	s.Variant = "1"
+	if s.EAN13() != "" {
+		t.Errorf("ISSN.EAN13() with variant 1 == %q, want \"\"", s.EAN13())
+	}
+
+	// An unparsed ISSN can't be formatted
+	var x ISSN
+	_, err := x.Hyphenated()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISSN{}.Hyphenated() error == %v, want ErrNotValid", err)
+	}
+	if x.ISSN() != "" || x.EAN13() != "" || x.String() != "" {
+		t.Errorf("ISSN{} formats as %q, want \"\"", x.ISSN())
+	}
+}
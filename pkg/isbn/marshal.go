@@ -0,0 +1,186 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface. The ISBN
+// is marshalled as the compact ISBN-13 (and the zero ISBN as the empty
+// string). As encoding/xml uses MarshalText this also provides for
+// ISBNs as both XML elements and attributes.
+func (x ISBN) MarshalText() ([]byte, error) {
+	if x == (ISBN{}) {
+		return []byte{}, nil
+	}
+	if !x.IsValid {
+		return nil, ErrNotValid
+	}
+	return []byte(x.ISBN13()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The
+// text is parsed using the range data loaded by LoadRangeData. Empty
+// text unmarshals as the zero ISBN.
+func (x *ISBN) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*x = ISBN{}
+		return nil
+	}
+	v, err := ParseISBN(string(text))
+	if err != nil {
+		return err
+	}
+	*x = v
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The ISBN is
+// marshalled as a string containing the compact ISBN-13 (use Detailed
+// for the object form).
+func (x ISBN) MarshalJSON() ([]byte, error) {
+	b, err := x.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Either a
+// string or the object form (see Detailed) is accepted. Null leaves
+// the ISBN unchanged.
+func (x *ISBN) UnmarshalJSON(data []byte) error {
+
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var d Detailed
+		err := d.UnmarshalJSON(data)
+		if err != nil {
+			return err
+		}
+		*x = ISBN(d)
+		return nil
+	}
+
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	return x.UnmarshalText([]byte(s))
+}
+
+// Detailed is an ISBN that marshals to JSON as an object containing
+// all of the elements of the ISBN, the agency, and both the compact
+// and hyphenated forms, i.e.:
+//
+//	{
+//	  "isbn13": "9780547928241",
+//	  "isbn10": "0547928246",
+//	  "hyphenated13": "978-0-547-92824-1",
+//	  "hyphenated10": "0-547-92824-6",
+//	  "prefix": "978",
+//	  "registrationGroup": "0",
+//	  "registrant": "547",
+//	  "publication": "92824",
+//	  "checkDigit13": "1",
+//	  "checkDigit10": "6",
+//	  "agency": "English language"
+//	}
+//
+// Forms that the ISBN does not have (such as the ISBN-10 of a 979
+// ISBN) are omitted.
+type Detailed ISBN
+
+// detailedJSON is the JSON object form of a Detailed ISBN.
+type detailedJSON struct {
+	ISBN13            string `json:"isbn13"`
+	ISBN10            string `json:"isbn10,omitempty"`
+	Hyphenated13      string `json:"hyphenated13"`
+	Hyphenated10      string `json:"hyphenated10,omitempty"`
+	Prefix            string `json:"prefix"`
+	RegistrationGroup string `json:"registrationGroup"`
+	Registrant        string `json:"registrant"`
+	Publication       string `json:"publication"`
+	CheckDigit13      string `json:"checkDigit13"`
+	CheckDigit10      string `json:"checkDigit10,omitempty"`
+	Agency            string `json:"agency"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d Detailed) MarshalJSON() ([]byte, error) {
+
+	x := ISBN(d)
+	if x == (ISBN{}) {
+		return []byte("null"), nil
+	}
+	if !x.IsValid {
+		return nil, ErrNotValid
+	}
+
+	h13, _ := x.Hyphenated13()
+	h10, _ := x.Hyphenated10()
+
+	return json.Marshal(detailedJSON{
+		ISBN13:            x.ISBN13(),
+		ISBN10:            x.ISBN10(),
+		Hyphenated13:      h13,
+		Hyphenated10:      h10,
+		Prefix:            x.Prefix,
+		RegistrationGroup: x.RegistrationGroup,
+		Registrant:        x.Registrant,
+		Publication:       x.Publication,
+		CheckDigit13:      x.CheckDigit13,
+		CheckDigit10:      x.CheckDigit10,
+		Agency:            x.Agency,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Rather than
+// trusting the elements in the object, the ISBN-13 (or, failing that,
+// the ISBN-10) is re-parsed using the range data loaded by
+// LoadRangeData. A string is also accepted.
+func (d *Detailed) UnmarshalJSON(data []byte) error {
+
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '{' {
+		var x ISBN
+		err := x.UnmarshalJSON(data)
+		if err != nil {
+			return err
+		}
+		*d = Detailed(x)
+		return nil
+	}
+
+	var dj detailedJSON
+	err := json.Unmarshal(data, &dj)
+	if err != nil {
+		return err
+	}
+
+	s := dj.ISBN13
+	if s == "" {
+		s = dj.ISBN10
+	}
+
+	var x ISBN
+	err = x.UnmarshalText([]byte(s))
+	if err != nil {
+		return err
+	}
+	*d = Detailed(x)
+	return nil
+}
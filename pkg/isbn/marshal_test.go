@@ -0,0 +1,156 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"0547928246", "9780547928241"},
+		{"978-0-670-01395-1", "9780670013951"},
+		{"9791012345678", "9791012345678"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		var x ISBN
+		err := x.UnmarshalText([]byte(c.in))
+		if err != nil {
+			t.Errorf("UnmarshalText(%q) == fail, want success (%q)", c.in, err)
+			continue
+		}
+		got, err := x.MarshalText()
+		if err != nil || string(got) != c.want {
+			t.Errorf("MarshalText() == %q, want %q (%v)", got, c.want, err)
+		}
+	}
+
+	var x ISBN
+	err := x.UnmarshalText([]byte("9780590732053"))
+	if !errors.Is(err, ErrInvalidCheckDigit) {
+		t.Errorf("UnmarshalText(%q) == %v, want ErrInvalidCheckDigit", "9780590732053", err)
+	}
+
+	x = ISBN{Prefix: p978}
+	_, err = x.MarshalText()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("MarshalText() of an invalid ISBN == %v, want ErrNotValid", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestMarshalJSON(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	type book struct {
+		Title string   `json:"title"`
+		ISBN  ISBN     `json:"isbn"`
+		Info  Detailed `json:"info"`
+	}
+
+	x, _ := ParseISBN("0547928246")
+	b := book{Title: "The Hobbit", ISBN: x, Info: Detailed(x)}
+
+	got, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("json.Marshal() == fail, want success (%q)", err)
+	}
+	want := `{"title":"The Hobbit","isbn":"9780547928241","info":{"isbn13":"9780547928241","isbn10":"0547928246",` +
+		`"hyphenated13":"978-0-547-92824-1","hyphenated10":"0-547-92824-6","prefix":"978","registrationGroup":"0",` +
+		`"registrant":"547","publication":"92824","checkDigit13":"1","checkDigit10":"6","agency":"English language"}}`
+	if string(got) != want {
+		t.Errorf("json.Marshal() == %s, want %s", got, want)
+	}
+
+	var rt book
+	err = json.Unmarshal(got, &rt)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() == fail, want success (%q)", err)
+	}
+	if rt != b {
+		t.Errorf("json.Unmarshal() == %+v, want %+v", rt, b)
+	}
+
+	// Either form is accepted by either type
+	cases := []string{
+		`{"isbn":"978-0-547-92824-1","info":"0547928246"}`,
+		`{"isbn":{"isbn13":"9780547928241"},"info":{"isbn10":"0547928246"}}`,
+	}
+	for _, in := range cases {
+		var v book
+		err := json.Unmarshal([]byte(in), &v)
+		if err != nil {
+			t.Errorf("json.Unmarshal(%s) == fail, want success (%q)", in, err)
+		} else if v.ISBN != x || ISBN(v.Info) != x {
+			t.Errorf("json.Unmarshal(%s) == %+v, want %v", in, v, x)
+		}
+	}
+
+	var v book
+	err = json.Unmarshal([]byte(`{"isbn":"9780590732053"}`), &v)
+	if !errors.Is(err, ErrInvalidCheckDigit) {
+		t.Errorf("json.Unmarshal() of an invalid ISBN == %v, want ErrInvalidCheckDigit", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestMarshalXML(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	type book struct {
+		XMLName xml.Name `xml:"book"`
+		ID      ISBN     `xml:"id,attr"`
+		ISBN    ISBN     `xml:"isbn"`
+	}
+
+	x, _ := ParseISBN("978 0670013951")
+	b := book{ID: x, ISBN: x}
+
+	got, err := xml.Marshal(b)
+	if err != nil {
+		t.Fatalf("xml.Marshal() == fail, want success (%q)", err)
+	}
+	want := `<book id="9780670013951"><isbn>9780670013951</isbn></book>`
+	if string(got) != want {
+		t.Errorf("xml.Marshal() == %s, want %s", got, want)
+	}
+
+	var rt book
+	err = xml.Unmarshal([]byte(`<book id="0-670-01395-1"><isbn>978-0-670-01395-1</isbn></book>`), &rt)
+	if err != nil {
+		t.Fatalf("xml.Unmarshal() == fail, want success (%q)", err)
+	}
+	if rt.ID != x || rt.ISBN != x {
+		t.Errorf("xml.Unmarshal() == %+v, want %v", rt, x)
+	}
+
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,301 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChangeKind identifies a kind of change made by a Normalizer.
+type ChangeKind int
+
+// The kinds of change that a Normalizer makes.
+const (
+	// ChangeSpace is the removal of a space (ASCII whitespace, or any
+	// Unicode space when Normalizer.Spaces is set).
+	ChangeSpace ChangeKind = iota
+	// ChangeHyphen is the removal of an ASCII hyphen-minus.
+	ChangeHyphen
+	// ChangeCase is the upper-casing of a letter (such as an x check
+	// digit).
+	ChangeCase
+	// ChangeWidth is the folding of a full-width character to ASCII.
+	ChangeWidth
+	// ChangeDash is the removal of a Unicode dash (en dash, em dash,
+	// minus sign, etc.).
+	ChangeDash
+	// ChangePunctuation is the removal of a dot or underscore.
+	ChangePunctuation
+	// ChangeLabel is the removal of a leading "ISBN", "ISBN-10:",
+	// "ISBN-13:" (etc.) label.
+	ChangeLabel
+	// ChangeQualifier is the removal of a trailing parenthesised
+	// qualifier such as "(pbk.)".
+	ChangeQualifier
+)
+
+// String implements the Stringer interface.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeSpace:
+		return "space"
+	case ChangeHyphen:
+		return "hyphen"
+	case ChangeCase:
+		return "case"
+	case ChangeWidth:
+		return "width"
+	case ChangeDash:
+		return "dash"
+	case ChangePunctuation:
+		return "punctuation"
+	case ChangeLabel:
+		return "label"
+	case ChangeQualifier:
+		return "qualifier"
+	}
+	return "unknown"
+}
+
+// A Change records a single change that a Normalizer made to its input.
+type Change struct {
+	Kind ChangeKind
+	Pos  int    // the byte offset in the input of the changed text
+	From string // the text as it was in the input
+	To   string // the text it was changed to (empty for removals)
+}
+
+// String implements the Stringer interface.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %q -> %q at %d", c.Kind, c.From, c.To, c.Pos)
+}
+
+// A Normalizer normalises ISBNs before they are parsed. The zero value
+// (StrictNormalizer) only makes the changes that ParseISBN has always
+// made: removing ASCII whitespace and hyphens and upper-casing. The
+// remaining changes are enabled individually.
+type Normalizer struct {
+	Width       bool // fold full-width digits, letters and punctuation to ASCII
+	Spaces      bool // remove Unicode spaces (non-breaking, thin, etc.)
+	Dashes      bool // remove Unicode dashes (en dash, em dash, minus sign, etc.)
+	Punctuation bool // remove dots and underscores
+	Labels      bool // remove a leading "ISBN", "ISBN-10:", "ISBN-13:" (etc.) label
+	Qualifiers  bool // remove trailing parenthesised qualifiers such as "(pbk.)"
+}
+
+// The normalisation profiles.
+var (
+	// StrictNormalizer makes only the changes that ParseISBN makes.
+	StrictNormalizer = Normalizer{}
+
+	// LenientNormalizer makes every change that a Normalizer can make
+	// in order to deal with ISBNs from real world sources such as
+	// spreadsheets and web forms.
+	LenientNormalizer = Normalizer{
+		Width:       true,
+		Spaces:      true,
+		Dashes:      true,
+		Punctuation: true,
+		Labels:      true,
+		Qualifiers:  true,
+	}
+)
+
+// token is a (possibly changed) rune of the input and the position of
+// the rune in the input.
+type token struct {
+	r    rune
+	pos  int
+	size int
+}
+
+// Normalize normalises the ISBN and returns the result along with the
+// changes that were made, in the order that they were made.
+func (n Normalizer) Normalize(isbn string) (string, []Change) {
+
+	var changes []Change
+
+	toks := make([]token, 0, len(isbn))
+	for pos, r := range isbn {
+		_, size := utf8.DecodeRuneInString(isbn[pos:])
+		toks = append(toks, token{r: r, pos: pos, size: size})
+	}
+
+	if n.Width {
+		for i, t := range toks {
+			w := foldWidth(t.r)
+			if w != t.r {
+				changes = append(changes, Change{Kind: ChangeWidth, Pos: t.pos, From: string(t.r), To: string(w)})
+				toks[i].r = w
+			}
+		}
+	}
+
+	if n.Labels {
+		if end := labelEnd(toks); end > 0 {
+			changes = append(changes, Change{Kind: ChangeLabel, Pos: toks[0].pos, From: isbn[toks[0].pos : toks[end-1].pos+toks[end-1].size]})
+			toks = toks[end:]
+		}
+	}
+
+	if n.Qualifiers {
+		for {
+			start, end := qualifierSpan(toks)
+			if start < 0 {
+				break
+			}
+			changes = append(changes, Change{Kind: ChangeQualifier, Pos: toks[start].pos, From: isbn[toks[start].pos : toks[end-1].pos+toks[end-1].size]})
+			toks = toks[:start]
+		}
+	}
+
+	out := make([]rune, 0, len(toks))
+	for _, t := range toks {
+		kind, remove := n.removal(t.r)
+		if remove {
+			changes = append(changes, Change{Kind: kind, Pos: t.pos, From: isbn[t.pos : t.pos+t.size]})
+			continue
+		}
+		u := unicode.ToUpper(t.r)
+		if u != t.r {
+			changes = append(changes, Change{Kind: ChangeCase, Pos: t.pos, From: string(t.r), To: string(u)})
+		}
+		out = append(out, u)
+	}
+
+	return string(out), changes
+}
+
+// removal returns whether or not the rune is to be removed and, if so,
+// the kind of change that removing it is.
+func (n Normalizer) removal(r rune) (ChangeKind, bool) {
+	switch {
+	case r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r':
+		return ChangeSpace, true
+	case r == '-':
+		return ChangeHyphen, true
+	case n.Spaces && unicode.IsSpace(r):
+		return ChangeSpace, true
+	case n.Spaces && (r == '\u200b' || r == '\ufeff'):
+		// zero width space, byte order mark
+		return ChangeSpace, true
+	case n.Dashes && isDash(r):
+		return ChangeDash, true
+	case n.Punctuation && (r == '.' || r == '_' || r == '\u00b7'):
+		return ChangePunctuation, true
+	}
+	return 0, false
+}
+
+// isDash reports whether r is a Unicode dash (other than the ASCII
+// hyphen-minus).
+func isDash(r rune) bool {
+	switch r {
+	case '\u00ad', '\u2212', '\ufe58', '\ufe63', '\uff0d':
+		return true
+	}
+	return r >= '\u2010' && r <= '\u2015'
+}
+
+// foldWidth folds a full-width (or ideographic space) rune to ASCII.
+func foldWidth(r rune) rune {
+	switch {
+	case r >= '\uff01' && r <= '\uff5e':
+		return r - '\uff01' + '!'
+	case r == '\u3000':
+		return ' '
+	}
+	return r
+}
+
+// labelEnd returns the number of tokens taken by a leading "ISBN"
+// label (including any "-10"/"-13", colon and surrounding spaces), or
+// 0 if there is no label.
+func labelEnd(toks []token) int {
+
+	i := skipSpaces(toks, 0)
+	for _, c := range "ISBN" {
+		if i >= len(toks) || unicode.ToUpper(toks[i].r) != c {
+			return 0
+		}
+		i++
+	}
+
+	// Optional -10 or -13 (and the separator before it)
+	j := i
+	if j < len(toks) && (toks[j].r == '-' || toks[j].r == ' ' || isDash(toks[j].r)) {
+		j++
+	}
+	if j+1 < len(toks) && toks[j].r == '1' && (toks[j+1].r == '0' || toks[j+1].r == '3') {
+		// Only a label if it is followed by a separator (otherwise it
+		// is the start of the ISBN itself)
+		k := j + 2
+		if k < len(toks) && (toks[k].r == ':' || toks[k].r == '#' || unicode.IsSpace(toks[k].r)) {
+			i = k
+		}
+	}
+
+	i = skipSpaces(toks, i)
+	if i < len(toks) && (toks[i].r == ':' || toks[i].r == '#') {
+		i++
+	}
+	return skipSpaces(toks, i)
+}
+
+// qualifierSpan returns the span of a trailing parenthesised (or
+// bracketed) qualifier, including any surrounding spaces. start is -1
+// if there is no qualifier.
+func qualifierSpan(toks []token) (start, end int) {
+
+	end = len(toks)
+	i := end - 1
+	for i >= 0 && unicode.IsSpace(toks[i].r) {
+		i--
+	}
+	if i < 0 || (toks[i].r != ')' && toks[i].r != ']') {
+		return -1, 0
+	}
+
+	open := '('
+	if toks[i].r == ']' {
+		open = '['
+	}
+	for i >= 0 && toks[i].r != open {
+		i--
+	}
+	if i < 0 {
+		return -1, 0
+	}
+	for i > 0 && unicode.IsSpace(toks[i-1].r) {
+		i--
+	}
+	return i, end
+}
+
+// skipSpaces returns the position of the first non-space token at or
+// after i.
+func skipSpaces(toks []token, i int) int {
+	for i < len(toks) && unicode.IsSpace(toks[i].r) {
+		i++
+	}
+	return i
+}
+
+// ParseISBNWith normalises the ISBN using n and then parses it using
+// the range data loaded by LoadRangeData.
+func ParseISBNWith(n Normalizer, isbn string) (ISBN, []Change, error) {
+	return defaultRangeData.ParseISBNWith(n, isbn)
+}
+
+// ParseISBNWith normalises the ISBN using n and then parses it using
+// the range data in r. The changes that were made in normalising the
+// ISBN are returned whether or not it parses.
+func (r *RangeData) ParseISBNWith(n Normalizer, isbn string) (ISBN, []Change, error) {
+	s, changes := n.Normalize(isbn)
+	x, err := r.ParseISBN(s)
+	return x, changes, err
+}
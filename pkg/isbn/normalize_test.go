@@ -0,0 +1,109 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"testing"
+)
+
+func TestNormalizeStrict(t *testing.T) {
+
+	// The strict profile makes the same changes as ParseISBN
+	cases := []string{
+		"88 04 47328 2",
+		"978-8804473282",
+		"089686281x",
+		"\t978-0-547-92824-1\r\n",
+		"9780590d32053",
+		"978–054792824 1",
+		"ISBN 978-0-547-92824-1",
+		"",
+	}
+	for _, c := range cases {
+		got, _ := StrictNormalizer.Normalize(c)
+		want := stripISBN(c)
+		if got != want {
+			t.Errorf("StrictNormalizer.Normalize(%q) == %q, want %q", c, got, want)
+		}
+	}
+}
+
+func TestNormalizeLenient(t *testing.T) {
+
+	cases := []struct {
+		in    string
+		want  string
+		kinds []ChangeKind
+	}{
+		{"978-0547928241", "9780547928241", []ChangeKind{ChangeHyphen}},
+		{"089686281x", "089686281X", []ChangeKind{ChangeCase}},
+		{"978–0–547—92824–1", "9780547928241", []ChangeKind{ChangeDash, ChangeDash, ChangeDash, ChangeDash}},
+		{"978 0 547 92824 1", "9780547928241", []ChangeKind{ChangeSpace, ChangeSpace, ChangeSpace, ChangeSpace}},
+		{"978.0.547.92824_1", "9780547928241", []ChangeKind{ChangePunctuation, ChangePunctuation, ChangePunctuation, ChangePunctuation}},
+		{"ISBN: 0547928246", "0547928246", []ChangeKind{ChangeLabel}},
+		{"isbn-13: 9780547928241", "9780547928241", []ChangeKind{ChangeLabel}},
+		{"ISBN 10 0547928246", "0547928246", []ChangeKind{ChangeLabel}},
+		{"ISBN13:9780547928241", "9780547928241", []ChangeKind{ChangeLabel}},
+		{"0547928246 (pbk.)", "0547928246", []ChangeKind{ChangeQualifier}},
+		{"0547928246 (pbk.) [alk. paper]", "0547928246", []ChangeKind{ChangeQualifier, ChangeQualifier}},
+		{
+			"ＩＳＢＮ　９７８–０",
+			"9780",
+			[]ChangeKind{
+				ChangeWidth, ChangeWidth, ChangeWidth, ChangeWidth, ChangeWidth,
+				ChangeWidth, ChangeWidth, ChangeWidth, ChangeWidth,
+				ChangeLabel, ChangeDash,
+			},
+		},
+	}
+	for _, c := range cases {
+		got, changes := LenientNormalizer.Normalize(c.in)
+		if got != c.want {
+			t.Errorf("LenientNormalizer.Normalize(%q) == %q, want %q", c.in, got, c.want)
+		}
+		if len(changes) != len(c.kinds) {
+			t.Errorf("LenientNormalizer.Normalize(%q) changes == %v, want %v", c.in, changes, c.kinds)
+			continue
+		}
+		for i, k := range c.kinds {
+			if changes[i].Kind != k {
+				t.Errorf("LenientNormalizer.Normalize(%q) changes[%d] == %v, want %s", c.in, i, changes[i], k)
+			}
+			from := changes[i].From
+			if c.in[changes[i].Pos:changes[i].Pos+len(from)] != from {
+				t.Errorf("LenientNormalizer.Normalize(%q) changes[%d] == %v, not at %d", c.in, i, changes[i], changes[i].Pos)
+			}
+		}
+	}
+}
+
+func TestParseISBNWith(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in     string
+		n      Normalizer
+		want13 string
+	}{
+		{"ISBN 978–0–547–92824–1 (hbk.)", LenientNormalizer, "9780547928241"},
+		{"０５４７９２８２４６", LenientNormalizer, "9780547928241"},
+		{"ISBN 978–0–547–92824–1 (hbk.)", StrictNormalizer, ""},
+		{"978-0-547-92824-1", StrictNormalizer, "9780547928241"},
+		{"978 0 547 92824 1", Normalizer{Dashes: true}, "9780547928241"},
+	}
+	for _, c := range cases {
+		x, changes, err := ParseISBNWith(c.n, c.in)
+		if x.ISBN13() != c.want13 {
+			t.Errorf("ParseISBNWith(%+v, %q) == %q, want %q (%v, %v)", c.n, c.in, x.ISBN13(), c.want13, changes, err)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
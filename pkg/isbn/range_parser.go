@@ -5,11 +5,19 @@
 package isbn
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/xml"
 	"errors"
+	"io"
+	"io/fs"
 	"log"
 	"os"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // rangeMessageXML is for containing the contents of the RangeMessage.xml
@@ -80,30 +88,206 @@ type rangeMessageXML struct {
 type registrant struct {
 	Agency string
 	Ranges [][]int
+	index  intervalIndex
+}
+
+// An interval maps a range of the seven digit values that follow an
+// element of an ISBN to the length of the next element.
+type interval struct {
+	lo     int
+	hi     int
+	length int
+}
+
+// An intervalIndex contains non-overlapping intervals sorted by lo so
+// that the interval for a value can be found with a binary search.
+type intervalIndex []interval
+
+// newIntervalIndex returns an intervalIndex for the [start, end,
+// length] rules. If truncated is set then the start and end of each
+// rule are only the first length digits of the range (as the
+// registrant rules are stored) and are widened to seven digit values,
+// otherwise they are already seven digit values (as the EAN.UCC rules
+// are stored).
+func newIntervalIndex(rules [][]int, truncated bool) intervalIndex {
+	ix := make(intervalIndex, 0, len(rules))
+	for _, rule := range rules {
+		scale := 1
+		if truncated {
+			scale = pow10(7 - rule[2])
+		}
+		ix = append(ix, interval{
+			lo:     rule[0] * scale,
+			hi:     (rule[1]+1)*scale - 1,
+			length: rule[2],
+		})
+	}
+	sort.SliceStable(ix, func(i, j int) bool { return ix[i].lo < ix[j].lo })
+	return ix
+}
+
+// lookup returns the length of the element for the value (the seven
+// digits that follow the preceding element). ok is false if the value
+// is not in any of the intervals.
+func (ix intervalIndex) lookup(value int) (length int, ok bool) {
+	i := sort.Search(len(ix), func(i int) bool { return ix[i].lo > value }) - 1
+	if i < 0 || value > ix[i].hi {
+		return 0, false
+	}
+	return ix[i].length, true
+}
+
+// sevenDigits returns the value of the first seven digits of b (padded
+// on the right with zeros when b is shorter than seven digits).
+func sevenDigits(b []byte) int {
+	v := 0
+	for i := 0; i < 7; i++ {
+		v *= 10
+		if i < len(b) {
+			v += int(b[i] - '0')
+		}
+	}
+	return v
 }
 
 type rangeData map[string]map[string]registrant
 
-var rmd = make(rangeData)
+// rangeTable contains a complete set of range data. Once a rangeTable
+// has been built it is never modified; reloading the range data builds
+// a new rangeTable which then replaces the old one.
+type rangeTable struct {
+	rmd  rangeData
+	ean  eanData
+	info RangeMessage
+}
+
+// eanData contains the EAN.UCC prefix rules. For each prefix the rules
+// map the seven digits following the prefix to the length of the
+// registration group element (0 for ranges that are not allocated).
+type eanData map[string]intervalIndex
+
+// RangeMessage contains the metadata identifying the RangeMessage.xml
+// file that range data was loaded from.
+type RangeMessage struct {
+	Source       string
+	SerialNumber string
+	Date         string
+}
+
+// rangeMessageDateFormat is the format used for the MessageDate in
+// RangeMessage.xml files (i.e. "Sat, 9 Dec 2023 15:26:25 GMT").
+const rangeMessageDateFormat = "Mon, 2 Jan 2006 15:04:05 MST"
+
+// Time returns the MessageDate of the RangeMessage.xml file as a
+// time.Time.
+func (m RangeMessage) Time() (time.Time, error) {
+	return time.Parse(rangeMessageDateFormat, m.Date)
+}
+
+// String implements the Stringer interface.
+func (m RangeMessage) String() string {
+	if m == (RangeMessage{}) {
+		return ""
+	}
+	return m.Source + " " + m.SerialNumber + " (" + m.Date + ")"
+}
+
+// groupLength returns the length of the registration group element
+// for the seven digits (rest) following the EAN.UCC prefix. A length
+// of 0 indicates that the registration group has not been allocated.
+func (t *rangeTable) groupLength(prefix string, rest []byte) int {
+	length, _ := t.ean[prefix].lookup(sevenDigits(rest))
+	return length
+}
+
+// emptyTable is used by any RangeData that has no range data loaded.
+var emptyTable = &rangeTable{rmd: make(rangeData), ean: make(eanData)}
+
+// RangeData contains the ISBN range data from a single RangeMessage.xml
+// file. Each RangeData is independent of any other so that multiple
+// versions of the range data may be loaded side by side. The zero
+// value is an empty RangeData that is ready to have data loaded into
+// it.
+//
+// A RangeData is safe for concurrent use. Loading (or unloading) range
+// data swaps the complete new data set in atomically so that parsing
+// that is done concurrently with a reload will see either all of the
+// old data or all of the new data. A RangeData must not be copied
+// after first use.
+type RangeData struct {
+	table atomic.Pointer[rangeTable]
+}
+
+// defaultRangeData is the RangeData used by the package level
+// functions (LoadRangeData, ParseISBN, etc.)
+var defaultRangeData = new(RangeData)
+
+// NewRangeData returns a new RangeData that has been loaded from the
+// specified RangeMessage.xml file.
+func NewRangeData(filename string) (*RangeData, error) {
+	r := new(RangeData)
+	_, err := r.LoadRangeData(filename)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
 
 // HasRangeData is used for indicating whether or not the range data
 // has been loaded.
 func HasRangeData() bool {
-	return len(rmd) > 0
+	return defaultRangeData.HasRangeData()
+}
+
+// HasRangeData is used for indicating whether or not the range data
+// has been loaded into r.
+func (r *RangeData) HasRangeData() bool {
+	return len(r.rangeTable().rmd) > 0
+}
+
+// RangeMessageInfo returns the metadata (source, serial number and
+// date) of the loaded range data. The zero RangeMessage is returned if
+// no range data has been loaded.
+func RangeMessageInfo() RangeMessage {
+	return defaultRangeData.RangeMessageInfo()
+}
+
+// RangeMessageInfo returns the metadata (source, serial number and
+// date) of the range data loaded into r.
+func (r *RangeData) RangeMessageInfo() RangeMessage {
+	return r.rangeTable().info
+}
+
+// rangeTable returns the range data currently loaded into r. Callers
+// that need to make several lookups should call rangeTable once and
+// then use the result for all lookups so that a concurrent reload
+// cannot change the data part way through.
+func (r *RangeData) rangeTable() *rangeTable {
+	t := r.table.Load()
+	if t == nil {
+		return emptyTable
+	}
+	return t
 }
 
 // UnloadRangeData unloads any loaded RangeMessage.xml file data.
 // Probably not needed for production code; it is intended for testing
 // purposes.
 func UnloadRangeData() (bool, error) {
+	return defaultRangeData.UnloadRangeData()
+}
+
+// UnloadRangeData unloads any RangeMessage.xml file data that has been
+// loaded into r.
+func (r *RangeData) UnloadRangeData() (bool, error) {
 
-	rmd = make(rangeData)
+	r.table.Store(emptyTable)
 
 	// Yeah, yeah. Like this is going to break in it's current form.
 	// Mostly here for the sake of consistent interface and in case
 	// UnloadRangeData ever needs to do anything more complex that
 	// could break (won't need to re-code anything using this pkg)
-	if len(rmd) > 0 {
+	if r.HasRangeData() {
 		return false, errors.New("range data did not unload")
 	}
 	return true, nil
@@ -117,6 +301,14 @@ func UnloadRangeData() (bool, error) {
 // The RangeMessage.xml file to load should be available at:
 // https://www.isbn-international.org/range_file_generation
 func LoadRangeData(filename string) (bool, error) {
+	return defaultRangeData.LoadRangeData(filename)
+}
+
+// LoadRangeData loads a RangeMessage.xml file into r, replacing any
+// range data that r previously contained. The new range data is fully
+// built before it replaces the old so that concurrent calls to
+// ParseISBN never see partially loaded data.
+func (r *RangeData) LoadRangeData(filename string) (ok bool, err error) {
 
 	f, err := os.Open(filename)
 	if err != nil {
@@ -124,22 +316,140 @@ func LoadRangeData(filename string) (bool, error) {
 	}
 	defer func() {
 		if cerr := f.Close(); cerr != nil && err == nil {
-			err = cerr
+			ok, err = false, cerr
 		}
 	}()
 
-	dec := xml.NewDecoder(f)
-	var doc rangeMessageXML
-	if err = dec.Decode(&doc); err != nil {
+	return r.LoadRangeDataReader(f)
+}
+
+// LoadRangeDataReader loads the contents of a RangeMessage.xml file,
+// read from rd, for use in parsing and validating ISBNs. The contents
+// may optionally be gzip compressed.
+func LoadRangeDataReader(rd io.Reader) (bool, error) {
+	return defaultRangeData.LoadRangeDataReader(rd)
+}
+
+// LoadRangeDataReader loads the contents of a RangeMessage.xml file,
+// read from rd, into r replacing any range data that r previously
+// contained. The contents may optionally be gzip compressed.
+func (r *RangeData) LoadRangeDataReader(rd io.Reader) (bool, error) {
+
+	doc, err := decodeRangeMessage(rd)
+	if err != nil {
 		return false, err
 	}
 
-	// Just in case the data has already been loaded once, or there is
-	// a need to re-load the data.
-	_, err = UnloadRangeData()
+	r.table.Store(buildRangeTable(doc))
+
+	return true, nil
+}
+
+// LoadRangeDataBytes loads the contents of a RangeMessage.xml file
+// that has already been read into memory.
+func LoadRangeDataBytes(b []byte) (bool, error) {
+	return defaultRangeData.LoadRangeDataBytes(b)
+}
+
+// LoadRangeDataBytes loads the contents of a RangeMessage.xml file
+// that has already been read into memory into r, replacing any range
+// data that r previously contained.
+func (r *RangeData) LoadRangeDataBytes(b []byte) (bool, error) {
+	return r.LoadRangeDataReader(bytes.NewReader(b))
+}
+
+// LoadRangeDataFS loads the named RangeMessage.xml file from fsys
+// (such as an embed.FS).
+func LoadRangeDataFS(fsys fs.FS, name string) (bool, error) {
+	return defaultRangeData.LoadRangeDataFS(fsys, name)
+}
+
+// LoadRangeDataFS loads the named RangeMessage.xml file from fsys into
+// r, replacing any range data that r previously contained.
+func (r *RangeData) LoadRangeDataFS(fsys fs.FS, name string) (ok bool, err error) {
+
+	f, err := fsys.Open(name)
 	if err != nil {
 		return false, err
 	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			ok, err = false, cerr
+		}
+	}()
+
+	return r.LoadRangeDataReader(f)
+}
+
+// decodeRangeMessage decodes the contents of a RangeMessage.xml file.
+// Gzip compressed contents are detected by their magic number and
+// decompressed before decoding.
+func decodeRangeMessage(rd io.Reader) (doc rangeMessageXML, err error) {
+
+	br := bufio.NewReader(rd)
+	magic, _ := br.Peek(2)
+
+	var src io.Reader = br
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return doc, err
+		}
+		defer zr.Close()
+		src = zr
+	}
+
+	dec := xml.NewDecoder(src)
+	err = dec.Decode(&doc)
+	return doc, err
+}
+
+// buildRangeTable builds a new rangeTable from the decoded contents of
+// a RangeMessage.xml file.
+func buildRangeTable(doc rangeMessageXML) *rangeTable {
+
+	t := &rangeTable{rmd: make(rangeData), ean: make(eanData)}
+	t.info = RangeMessage{
+		Source:       strings.TrimSpace(doc.MessageSource.Text),
+		SerialNumber: strings.TrimSpace(doc.MessageSerialNumber.Text),
+		Date:         strings.TrimSpace(doc.MessageDate.Text),
+	}
+
+	eanRules := make(map[string][][]int)
+
+	for _, eu := range doc.EANUCCPrefixes.EANUCC {
+		prefix := strings.TrimSpace(eu.Prefix.Text)
+
+		for _, rule := range eu.Rules.Rule {
+			rLen, err := toInt([]byte(rule.Length.Text))
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			tokens := strings.Split(rule.Range.Text, "-")
+			if len(tokens) != 2 {
+				log.Printf("invalid EAN.UCC range %q", rule.Range.Text)
+				continue
+			}
+			rStart, err := toInt([]byte(tokens[0]))
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			rEnd, err := toInt([]byte(tokens[1]))
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			eanRules[prefix] = append(eanRules[prefix], []int{rStart, rEnd, rLen})
+		}
+	}
+
+	for prefix, rules := range eanRules {
+		t.ean[prefix] = newIntervalIndex(rules, false)
+	}
 
 	for _, rg := range doc.RegistrationGroups.Group {
 		tokens := strings.Split(rg.Prefix.Text, "-")
@@ -170,7 +480,7 @@ func LoadRangeData(filename string) (bool, error) {
 					continue
 				}
 
-				if rEnd == 0 {
+				if rStart > rEnd {
 					continue
 				}
 
@@ -182,11 +492,13 @@ func LoadRangeData(filename string) (bool, error) {
 			}
 		}
 
-		if rmd[prefix] == nil {
-			rmd[prefix] = make(map[string]registrant)
+		reg.index = newIntervalIndex(reg.Ranges, true)
+
+		if t.rmd[prefix] == nil {
+			t.rmd[prefix] = make(map[string]registrant)
 		}
-		rmd[prefix][group] = reg
+		t.rmd[prefix][group] = reg
 	}
 
-	return true, nil
+	return t
 }
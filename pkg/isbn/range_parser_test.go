@@ -5,16 +5,36 @@
 package isbn
 
 import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 )
 
-func TestLoadRangeData(t *testing.T) {
+// fixtureFile is a small, hand-made range data file (not a copy of the
+// published RangeMessage.xml) that has only some of the registration
+// groups. It is tested with when the ISBN_RANGE_FILE Env variable is
+// not set. The tests that depend on which groups and registrant ranges
+// are (or are not) in the range data use minimalXML instead.
+const fixtureFile = "testdata/RangeMessage-fixture.xml"
 
+// rangeFile returns the name of the range data file to test with. That
+// is the file named by the ISBN_RANGE_FILE Env variable or, if it is
+// not set, the fixture.
+func rangeFile() string {
 	xmlFile := os.Getenv("ISBN_RANGE_FILE")
 	if xmlFile == "" {
-		t.Errorf("ISBN_RANGE_FILE Env variable not set")
+		return fixtureFile
 	}
+	return xmlFile
+}
+
+func TestLoadRangeData(t *testing.T) {
+
+	xmlFile := rangeFile()
 
 	// Before anything is loaded, the HasRangeData should return false
 	want := false
@@ -59,3 +79,341 @@ func TestLoadRangeData(t *testing.T) {
 		t.Errorf("UnloadRangeData() == %t, want %t", got, want)
 	}
 }
+
+func TestRangeDataInstances(t *testing.T) {
+
+	xmlFile := rangeFile()
+
+	rd, err := NewRangeData(xmlFile)
+	if err != nil {
+		t.Fatalf("NewRangeData(%q) failed (%q)", xmlFile, err)
+	}
+
+	// Loading into a RangeData should not affect the package level
+	// (default) range data, or any other RangeData
+	var empty RangeData
+	if empty.HasRangeData() {
+		t.Errorf("RangeData.HasRangeData() == true for an empty RangeData, want false")
+	}
+	if HasRangeData() {
+		t.Errorf("HasRangeData() == true, want false")
+	}
+	if !rd.HasRangeData() {
+		t.Errorf("RangeData.HasRangeData() == false, want true")
+	}
+
+	in := "978-0547928241"
+	_, err = rd.ParseISBN(in)
+	if err != nil {
+		t.Errorf("RangeData.ParseISBN(%q) == fail, want success (%q)", in, err)
+	}
+	_, err = empty.ParseISBN(in)
+	if err == nil {
+		t.Errorf("RangeData.ParseISBN(%q) == success for an empty RangeData, want fail", in)
+	}
+
+	// Unloading one RangeData should not affect another
+	_, err = NewRangeData("no-such-file.xml")
+	if err == nil {
+		t.Errorf("NewRangeData(%q) == success, want fail", "no-such-file.xml")
+	}
+	_, _ = empty.UnloadRangeData()
+	if !rd.HasRangeData() {
+		t.Errorf("RangeData.HasRangeData() == false after unloading another RangeData, want true")
+	}
+}
+
+func TestRangeDataConcurrentReload(t *testing.T) {
+
+	xmlFile := rangeFile()
+
+	rd, err := NewRangeData(xmlFile)
+	if err != nil {
+		t.Fatalf("NewRangeData(%q) failed (%q)", xmlFile, err)
+	}
+
+	// Parsing while the range data is being re-loaded should always
+	// see a complete set of range data
+	cases := []string{"88 04 47328 2", "978-0547928241", "089686281x", "978-8891230195"}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, in := range cases {
+					_, err := rd.ParseISBN(in)
+					if err != nil {
+						t.Errorf("RangeData.ParseISBN(%q) == fail during reload, want success (%q)", in, err)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		_, err := rd.LoadRangeData(xmlFile)
+		if err != nil {
+			t.Errorf("RangeData.LoadRangeData(%q) == fail, want success (%q)", xmlFile, err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestLoadRangeDataSources(t *testing.T) {
+
+	xmlFile := rangeFile()
+
+	b, err := os.ReadFile(xmlFile)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) failed (%q)", xmlFile, err)
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, _ = zw.Write(b)
+	_ = zw.Close()
+
+	in := "978-0547928241"
+
+	loaders := []struct {
+		name string
+		load func(r *RangeData) (bool, error)
+		want bool
+	}{
+		{"LoadRangeDataReader", func(r *RangeData) (bool, error) { return r.LoadRangeDataReader(bytes.NewReader(b)) }, true},
+		{"LoadRangeDataReader(gzip)", func(r *RangeData) (bool, error) { return r.LoadRangeDataReader(bytes.NewReader(gz.Bytes())) }, true},
+		{"LoadRangeDataBytes", func(r *RangeData) (bool, error) { return r.LoadRangeDataBytes(b) }, true},
+		{"LoadRangeDataBytes(gzip)", func(r *RangeData) (bool, error) { return r.LoadRangeDataBytes(gz.Bytes()) }, true},
+		{"LoadRangeDataBytes(bad)", func(r *RangeData) (bool, error) { return r.LoadRangeDataBytes([]byte("not xml")) }, false},
+		{"LoadRangeDataFS", func(r *RangeData) (bool, error) {
+			return r.LoadRangeDataFS(os.DirFS(filepath.Dir(xmlFile)), filepath.Base(xmlFile))
+		}, true},
+		{"LoadRangeDataFS(missing)", func(r *RangeData) (bool, error) {
+			return r.LoadRangeDataFS(os.DirFS(filepath.Dir(xmlFile)), "no-such-file.xml")
+		}, false},
+	}
+	for _, c := range loaders {
+		var rd RangeData
+		got, err := c.load(&rd)
+		if got != c.want {
+			t.Errorf("%s() == %t, want %t (%v)", c.name, got, c.want, err)
+			continue
+		}
+		if !c.want {
+			if err == nil {
+				t.Errorf("%s() == success, want an error", c.name)
+			}
+			if rd.HasRangeData() {
+				t.Errorf("%s() failed but RangeData.HasRangeData() == true", c.name)
+			}
+			continue
+		}
+		_, err = rd.ParseISBN(in)
+		if err != nil {
+			t.Errorf("%s(): RangeData.ParseISBN(%q) == fail, want success (%q)", c.name, in, err)
+		}
+	}
+}
+
+func TestRangeMessageInfo(t *testing.T) {
+
+	xmlFile := rangeFile()
+
+	var rd RangeData
+	if got := rd.RangeMessageInfo(); got != (RangeMessage{}) {
+		t.Errorf("RangeData.RangeMessageInfo() == %+v for an empty RangeData, want the zero value", got)
+	}
+
+	_, err := rd.LoadRangeData(xmlFile)
+	if err != nil {
+		t.Fatalf("RangeData.LoadRangeData(%q) failed (%q)", xmlFile, err)
+	}
+
+	info := rd.RangeMessageInfo()
+	if info.Source == "" || info.SerialNumber == "" || info.Date == "" {
+		t.Errorf("RangeData.RangeMessageInfo() == %+v, want all elements set", info)
+	}
+
+	_, err = info.Time()
+	if err != nil {
+		t.Errorf("RangeMessage.Time() for %q failed (%q)", info.Date, err)
+	}
+
+	_, _ = rd.UnloadRangeData()
+	if got := rd.RangeMessageInfo(); got != (RangeMessage{}) {
+		t.Errorf("RangeData.RangeMessageInfo() == %+v after unloading, want the zero value", got)
+	}
+}
+
+// linearRegistrantLength is the original registrant lookup (peeling one
+// digit at a time and checking every rule) that the interval index
+// replaced. It is kept for testing and benchmarking the index against.
+func linearRegistrantLength(rs registrant, rest []byte) int {
+	for i := 1; i <= len(rest); i++ {
+		chk, err := toInt(rest[:i])
+		if err != nil {
+			return 0
+		}
+		for _, rg := range rs.Ranges {
+			if i == rg[2] && chk >= rg[0] && chk <= rg[1] {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+func TestRegistrantIndex(t *testing.T) {
+
+	xmlFile := rangeFile()
+
+	rd, err := NewRangeData(xmlFile)
+	if err != nil {
+		t.Fatalf("NewRangeData(%q) failed (%q)", xmlFile, err)
+	}
+
+	// The index and the linear scan should agree for every group
+	for prefix, groups := range rd.rangeTable().rmd {
+		for group, rs := range groups {
+			width := elementDigits - len(group)
+			for v := 0; v < pow10(width); v += 1 + pow10(width)/5000 {
+				rest := []byte(fmt.Sprintf("%0*d", width, v))
+
+				want := linearRegistrantLength(rs, rest)
+				got, ok := rs.index.lookup(sevenDigits(rest))
+				if !ok {
+					got = 0
+				}
+				if got != want {
+					t.Errorf("%s-%s-%s: index == %d, linear == %d", prefix, group, rest, got, want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkRegistrantLinear(b *testing.B) {
+	rd, err := NewRangeData(rangeFile())
+	if err != nil {
+		b.Fatalf("NewRangeData() failed (%q)", err)
+	}
+	rs := rd.rangeTable().rmd["978"]["1"]
+	rest := []byte("99912345")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = linearRegistrantLength(rs, rest)
+	}
+}
+
+func BenchmarkRegistrantIndex(b *testing.B) {
+	rd, err := NewRangeData(rangeFile())
+	if err != nil {
+		b.Fatalf("NewRangeData() failed (%q)", err)
+	}
+	rs := rd.rangeTable().rmd["978"]["1"]
+	rest := []byte("99912345")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = rs.index.lookup(sevenDigits(rest))
+	}
+}
+
+// minimalXML is a purpose-made range message for the tests that need
+// range data of a particular shape (so that they pass whichever range
+// data file rangeFile returns):
+//
+//   - 978-90 is in the data and has a registrant range that is a single
+//     value (the one digit registrant 0) and an unused range (2...)
+//   - 978-91 is allocated by the EAN.UCC prefix rules but is not in the
+//     data
+//   - 978-95 to 978-99 and 979-11 to 979-99 are unallocated by the
+//     EAN.UCC prefix rules
+//   - 979-10 is in the data and has an unused registrant range (2...)
+const minimalXML = `<?xml version="1.0" encoding="utf-8"?>
+<ISBNRangeMessage>
+  <MessageSource>go-isbn test</MessageSource>
+  <MessageSerialNumber>minimal</MessageSerialNumber>
+  <MessageDate>Fri, 16 Oct 2026 00:00:00 GMT</MessageDate>
+  <EAN.UCCPrefixes>
+    <EAN.UCC>
+      <Prefix>978</Prefix>
+      <Agency>International ISBN Agency</Agency>
+      <Rules>
+        <Rule><Range>0000000-9499999</Range><Length>2</Length></Rule>
+        <Rule><Range>9500000-9999999</Range><Length>0</Length></Rule>
+      </Rules>
+    </EAN.UCC>
+    <EAN.UCC>
+      <Prefix>979</Prefix>
+      <Agency>International ISBN Agency</Agency>
+      <Rules>
+        <Rule><Range>0000000-0999999</Range><Length>0</Length></Rule>
+        <Rule><Range>1000000-1099999</Range><Length>2</Length></Rule>
+        <Rule><Range>1100000-9999999</Range><Length>0</Length></Rule>
+      </Rules>
+    </EAN.UCC>
+  </EAN.UCCPrefixes>
+  <RegistrationGroups>
+    <Group>
+      <Prefix>978-90</Prefix>
+      <Agency>Test</Agency>
+      <Rules>
+        <Rule><Range>0000000-0999999</Range><Length>1</Length></Rule>
+        <Rule><Range>1000000-1999999</Range><Length>2</Length></Rule>
+        <Rule><Range>2000000-9999999</Range><Length>0</Length></Rule>
+      </Rules>
+    </Group>
+    <Group>
+      <Prefix>979-10</Prefix>
+      <Agency>Test</Agency>
+      <Rules>
+        <Rule><Range>0000000-1999999</Range><Length>2</Length></Rule>
+        <Rule><Range>2000000-9999999</Range><Length>0</Length></Rule>
+      </Rules>
+    </Group>
+  </RegistrationGroups>
+</ISBNRangeMessage>`
+
+func TestSingleValueRegistrantRange(t *testing.T) {
+
+	var rd RangeData
+	_, err := rd.LoadRangeDataBytes([]byte(minimalXML))
+	if err != nil {
+		t.Fatalf("RangeData.LoadRangeDataBytes() failed (%q)", err)
+	}
+
+	cases := []struct {
+		in         string
+		registrant string
+	}{
+		{"9789001234560", "0"},
+		{"9789010123459", "10"},
+	}
+	for _, c := range cases {
+		x, err := rd.ParseISBN(c.in)
+		if err != nil || x.Registrant != c.registrant {
+			t.Errorf("RangeData.ParseISBN(%q) == %q (%v), want registrant %q", c.in, x.Registrant, err, c.registrant)
+		}
+	}
+
+	ranges, err := rd.RegistrantRanges("978", "90")
+	if err != nil || len(ranges) != 2 {
+		t.Fatalf("RangeData.RegistrantRanges(\"978\", \"90\") == %+v (%v), want 2 ranges", ranges, err)
+	}
+	if ranges[0].Start != "0" || ranges[0].End != "0" || ranges[0].Registrants != 1 {
+		t.Errorf("RangeData.RegistrantRanges(\"978\", \"90\")[0] == %+v, want the single registrant 0", ranges[0])
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+)
+
+// The Standard Book Number (SBN) is the 9-digit predecessor of the
+// ISBN that was used in the UK until 1974. An SBN is an ISBN-10 in the
+// 0 registration group without the leading 0 (SBN 340 01381 8 is ISBN
+// 0-340-01381-8) and has the same check digit.
+//
+// As a 9-digit number may equally be an ISBN that is missing a digit,
+// SBNs are only accepted by ParseSBN and never by ParseISBN.
+
+// ParseSBN parses the supplied SBN into the elements of the equivalent
+// ISBN using the range data loaded by LoadRangeData.
+func ParseSBN(sbn string) (ISBN, error) {
+	return defaultRangeData.ParseSBN(sbn)
+}
+
+// ParseSBN parses the supplied SBN into the elements of the equivalent
+// ISBN using the range data in r. As for ISBNs, spaces and hyphens are
+// ignored. The errors are *ParseErrors as for ParseISBN with the input
+// and positions being those of the SBN.
+func (r *RangeData) ParseSBN(sbn string) (ISBN, error) {
+
+	sbn = stripISBN(sbn)
+	if len(sbn) != 9 {
+		return ISBN{}, newParseError(StageLength, sbn, -1, ErrInvalidLength)
+	}
+
+	x, err := r.ParseISBN("0" + sbn)
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pos := pe.Pos
+			if pos > 0 {
+				pos--
+			}
+			err = newParseError(pe.Stage, sbn, pos, pe.Err)
+		}
+		return ISBN{}, err
+	}
+	return x, nil
+}
+
+// ValidateSBN tests whether or not the SBN parses using the range data
+// loaded by LoadRangeData.
+func ValidateSBN(sbn string) bool {
+	_, err := ParseSBN(sbn)
+	return err == nil
+}
+
+// SBN returns the ISBN as an SBN (as applicable).
+func (x ISBN) SBN() string {
+	if !x.hasSBN() {
+		return ""
+	}
+	return x.Registrant + x.Publication + x.CheckDigit10
+}
+
+// HyphenatedSBN returns the ISBN as a hyphenated SBN (i.e.
+// 340-01381-8). ErrNoSBN is returned for ISBNs that are not in the
+// 978-0 registration group.
+func (x ISBN) HyphenatedSBN() (string, error) {
+	if !x.IsValid {
+		return "", ErrNotValid
+	}
+	if !x.hasSBN() {
+		return "", ErrNoSBN
+	}
+	return x.Registrant + "-" + x.Publication + "-" + x.CheckDigit10, nil
+}
+
+// hasSBN reports whether the ISBN has an SBN form.
+func (x ISBN) hasSBN() bool {
+	return x.IsValid && x.Prefix == p978 && x.RegistrationGroup == "0"
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSBN(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in     string
+		want10 string
+		want13 string
+		sbn    string
+		hyph   string
+	}{
+		{"340 01381 8", "0340013818", "9780340013816", "340013818", "340-01381-8"},
+		{"547-92824-6", "0547928246", "9780547928241", "547928246", "547-92824-6"},
+		{"89686281x", "089686281X", "9780896862814", "89686281X", "89686-281-X"},
+	}
+	for _, c := range cases {
+		x, err := ParseSBN(c.in)
+		if err != nil {
+			t.Errorf("ParseSBN(%q) error == %v, want nil", c.in, err)
+			continue
+		}
+		if x.ISBN10() != c.want10 {
+			t.Errorf("ParseSBN(%q).ISBN10() == %q, want %q", c.in, x.ISBN10(), c.want10)
+		}
+		if x.ISBN13() != c.want13 {
+			t.Errorf("ParseSBN(%q).ISBN13() == %q, want %q", c.in, x.ISBN13(), c.want13)
+		}
+		if x.SBN() != c.sbn {
+			t.Errorf("ParseSBN(%q).SBN() == %q, want %q", c.in, x.SBN(), c.sbn)
+		}
+		got, _ := x.HyphenatedSBN()
+		if got != c.hyph {
+			t.Errorf("ParseSBN(%q).HyphenatedSBN() == %q, want %q", c.in, got, c.hyph)
+		}
+		if !ValidateSBN(c.in) {
+			t.Errorf("ValidateSBN(%q) == false, want true", c.in)
+		}
+
+		// ParseISBN never treats the SBN as an ISBN
+		_, err = ParseISBN(c.in)
+		if !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("ParseISBN(%q) error == %v, want ErrInvalidLength", c.in, err)
+		}
+	}
+
+	failures := []struct {
+		in    string
+		want  error
+		input string
+		pos   int
+	}{
+		{"340 01381 9", ErrInvalidCheckDigit, "340013819", 8},
+		{"340 01381", ErrInvalidLength, "34001381", -1},
+		{"0340013818", ErrInvalidLength, "0340013818", -1},
+		{"34X013818", ErrInvalidCharacter, "34X013818", 2},
+	}
+	for _, c := range failures {
+		_, err := ParseSBN(c.in)
+		if !errors.Is(err, c.want) {
+			t.Errorf("ParseSBN(%q) error == %v, want %v", c.in, err, c.want)
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Input != c.input || pe.Pos != c.pos {
+			t.Errorf("ParseSBN(%q) error == %#v, want {%q, %d}", c.in, err, c.input, c.pos)
+		}
+		if ValidateSBN(c.in) {
+			t.Errorf("ValidateSBN(%q) == true, want false", c.in)
+		}
+	}
+
+	// Only ISBNs in the 978-0 registration group have an SBN form
+	for _, in := range []string{"88-04-47328-2", "979-10-12-34567-8"} {
+		x, _ := ParseISBN(in)
+		_, err := x.HyphenatedSBN()
+		if !errors.Is(err, ErrNoSBN) || x.SBN() != "" {
+			t.Errorf("ParseISBN(%q).HyphenatedSBN() error == %v, want ErrNoSBN", in, err)
+		}
+	}
+	var x ISBN
+	_, err := x.HyphenatedSBN()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISBN{}.HyphenatedSBN() error == %v, want ErrNotValid", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
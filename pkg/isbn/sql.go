@@ -0,0 +1,128 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements the driver.Valuer interface. The ISBN is stored as
+// the compact ISBN-13 (and the zero ISBN as NULL).
+func (x ISBN) Value() (driver.Value, error) {
+	if x == (ISBN{}) {
+		return nil, nil
+	}
+	if !x.IsValid {
+		return nil, ErrNotValid
+	}
+	return x.ISBN13(), nil
+}
+
+// Scan implements the sql.Scanner interface. The stored value is
+// re-parsed using the range data loaded by LoadRangeData and an error
+// is returned if it no longer parses (use NullISBN for more control).
+// NULL scans as the zero ISBN.
+func (x *ISBN) Scan(src interface{}) error {
+
+	s, ok, err := scanString(src)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*x = ISBN{}
+		return nil
+	}
+	return x.UnmarshalText([]byte(s))
+}
+
+// ScanPolicy determines what NullISBN.Scan does when a stored value
+// does not parse as a valid ISBN (such as when the value was stored
+// using older range data).
+type ScanPolicy int
+
+// The ScanPolicies for NullISBN.
+const (
+	// ScanError returns the parse error from Scan.
+	ScanError ScanPolicy = iota
+	// ScanKeepRaw keeps the stored value in Raw, and leaves ISBN as
+	// the zero ISBN. Value then returns Raw unchanged so that the
+	// stored value is preserved when written back.
+	ScanKeepRaw
+	// ScanMarkInvalid keeps the stored value in Raw, and leaves ISBN
+	// as the zero ISBN (so ISBN.IsValid is false). Value then returns
+	// ErrNotValid so that the value cannot be written back.
+	ScanMarkInvalid
+)
+
+// NullISBN is an ISBN that may be NULL. It implements the sql.Scanner
+// and driver.Valuer interfaces so that it can be used as a scan
+// destination and as a query parameter.
+type NullISBN struct {
+	ISBN  ISBN
+	Valid bool   // Valid is true if the ISBN is not NULL
+	Raw   string // the stored value (as last scanned)
+
+	// OnInvalid is what Scan does when the stored value does not parse
+	// as a valid ISBN. The zero value is ScanError.
+	OnInvalid ScanPolicy
+}
+
+// Scan implements the sql.Scanner interface. The stored value is
+// re-parsed using the range data loaded by LoadRangeData; what happens
+// if it no longer parses depends on OnInvalid.
+func (n *NullISBN) Scan(src interface{}) error {
+
+	n.ISBN = ISBN{}
+	n.Valid = false
+	n.Raw = ""
+
+	s, ok, err := scanString(src)
+	if err != nil || !ok {
+		return err
+	}
+
+	n.Valid = true
+	n.Raw = s
+
+	err = n.ISBN.UnmarshalText([]byte(s))
+	if err != nil {
+		n.ISBN = ISBN{}
+		if n.OnInvalid == ScanError {
+			return err
+		}
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The ISBN is stored as
+// the compact ISBN-13. For a stored value that did not parse Value
+// depends on OnInvalid (see ScanPolicy).
+func (n NullISBN) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if !n.ISBN.IsValid {
+		if n.OnInvalid == ScanKeepRaw && n.Raw != "" {
+			return n.Raw, nil
+		}
+		return nil, ErrNotValid
+	}
+	return n.ISBN.ISBN13(), nil
+}
+
+// scanString converts a value scanned from a database to a string. ok
+// is false for NULL.
+func scanString(src interface{}) (s string, ok bool, err error) {
+	switch v := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+	return "", false, fmt.Errorf("cannot scan %T into an ISBN", src)
+}
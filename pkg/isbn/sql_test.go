@@ -0,0 +1,93 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSQLISBN(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in      interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"0547928246", "9780547928241", false},
+		{[]byte("978-0-670-01395-1"), "9780670013951", false},
+		{nil, nil, false},
+		{"9780590732053", nil, true},
+		{42, nil, true},
+	}
+	for _, c := range cases {
+		var x ISBN
+		err := x.Scan(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ISBN.Scan(%v) error == %v, want error %t", c.in, err, c.wantErr)
+			continue
+		}
+		got, err := x.Value()
+		if err != nil || got != c.want {
+			t.Errorf("ISBN.Value() after Scan(%v) == %v, want %v (%v)", c.in, got, c.want, err)
+		}
+	}
+
+	x := ISBN{Prefix: p978}
+	_, err := x.Value()
+	if !errors.Is(err, ErrNotValid) {
+		t.Errorf("ISBN.Value() of an invalid ISBN == %v, want ErrNotValid", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
+
+func TestSQLNullISBN(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in        interface{}
+		policy    ScanPolicy
+		wantErr   bool
+		valid     bool
+		isValid   bool
+		value     interface{}
+		valueFail bool
+	}{
+		{"0547928246", ScanError, false, true, true, "9780547928241", false},
+		{nil, ScanError, false, false, false, nil, false},
+		{"9780590732053", ScanError, true, true, false, nil, true},
+		{"9780590732053", ScanKeepRaw, false, true, false, "9780590732053", false},
+		{"9780590732053", ScanMarkInvalid, false, true, false, nil, true},
+	}
+	for _, c := range cases {
+		n := NullISBN{OnInvalid: c.policy}
+		err := n.Scan(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("NullISBN.Scan(%v) error == %v, want error %t", c.in, err, c.wantErr)
+		}
+		if n.Valid != c.valid || n.ISBN.IsValid != c.isValid {
+			t.Errorf("NullISBN.Scan(%v) == {Valid: %t, ISBN.IsValid: %t}, want {%t, %t}", c.in, n.Valid, n.ISBN.IsValid, c.valid, c.isValid)
+		}
+
+		got, err := n.Value()
+		if (err != nil) != c.valueFail || got != c.value {
+			t.Errorf("NullISBN.Value() after Scan(%v) == %v (%v), want %v", c.in, got, err, c.value)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Element identifies an element of an ISBN.
+type Element int
+
+// The elements of an ISBN.
+const (
+	ElementPrefix Element = iota
+	ElementGroup
+	ElementRegistrant
+	ElementPublication
+	ElementCheckDigit
+)
+
+// String implements the Stringer interface.
+func (e Element) String() string {
+	switch e {
+	case ElementPrefix:
+		return "prefix"
+	case ElementGroup:
+		return "registration group"
+	case ElementRegistrant:
+		return "registrant"
+	case ElementPublication:
+		return "publication"
+	case ElementCheckDigit:
+		return "check digit"
+	}
+	return "unknown"
+}
+
+// A HyphenationError records where the hyphenation of an ISBN differs
+// from the hyphenation given by the range data.
+type HyphenationError struct {
+	Input   string  // the ISBN as supplied
+	Want    string  // the ISBN as hyphenated by the range data
+	Pos     int     // the position in Input of the misplaced (or missing) hyphen
+	Element Element // the element that the hyphen is within (or is missing after)
+	Missing bool    // whether the hyphen is missing (rather than misplaced)
+}
+
+// Error implements the error interface.
+func (e *HyphenationError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("missing hyphen after the %s at position %d in %q, want %q", e.Element, e.Pos+1, e.Input, e.Want)
+	}
+	return fmt.Sprintf("misplaced hyphen in the %s at position %d in %q, want %q", e.Element, e.Pos+1, e.Input, e.Want)
+}
+
+// Unwrap returns ErrHyphenation.
+func (e *HyphenationError) Unwrap() error {
+	return ErrHyphenation
+}
+
+// ParseISBNStrict parses the ISBN, as ParseISBN does, using the range
+// data loaded by LoadRangeData and also checks the hyphenation of it.
+func ParseISBNStrict(isbn string) (ISBN, error) {
+	return defaultRangeData.ParseISBNStrict(isbn)
+}
+
+// ParseISBNStrict parses the ISBN, as ParseISBN does, using the range
+// data in r and also checks the hyphenation of it. Only an ISBN with no
+// hyphens or one that is hyphenated exactly as the range data
+// hyphenates it (i.e. 9780547928241 or 978-0-547-92824-1, but not
+// 978-05479-28241) is accepted. Spaces (other than leading and trailing
+// ones) are not accepted in place of the hyphens.
+//
+// An ISBN that is wrongly hyphenated is rejected with a
+// *HyphenationError that wraps ErrHyphenation and reports the first
+// hyphen that is misplaced or missing.
+func (r *RangeData) ParseISBNStrict(isbn string) (ISBN, error) {
+
+	x, err := r.ParseISBN(isbn)
+	if err != nil {
+		return x, err
+	}
+
+	input := strings.TrimSpace(isbn)
+	if !strings.ContainsAny(input, "- \t\n\f\r") {
+		return x, nil
+	}
+
+	// The number of digits in each element and where the elements are
+	// in the (ISBN-10 or ISBN-13) input
+	lengths := []int{
+		len(x.Prefix),
+		len(x.RegistrationGroup),
+		len(x.Registrant),
+		len(x.Publication),
+		1,
+	}
+	first := ElementPrefix
+	if len(stripISBN(input)) == 10 {
+		first = ElementGroup
+	}
+
+	// elementAt returns the element that the digit at position d (of
+	// the digits only) is in, and whether d is the first digit of it.
+	elementAt := func(d int) (Element, bool) {
+		e := first
+		for d >= lengths[e] && e < ElementCheckDigit {
+			d -= lengths[e]
+			e++
+		}
+		return e, d == 0
+	}
+
+	want := x.join(first == ElementGroup, "-")
+	digits := 0
+	hyphen := false // whether the previous character was a hyphen
+	for i := 0; i < len(input); i++ {
+		e, starts := elementAt(digits)
+		boundary := starts && digits > 0 && !hyphen
+		switch {
+		case input[i] == '-' && boundary:
+			hyphen = true
+		case input[i] == '-' || !boundary && isSpace(input[i]):
+			return ISBN{}, &HyphenationError{Input: input, Want: want, Pos: i, Element: e}
+		case boundary:
+			// A digit (or a space in place of the hyphen)
+			return ISBN{}, &HyphenationError{Input: input, Want: want, Pos: i, Element: e - 1, Missing: true}
+		default:
+			digits++
+			hyphen = false
+		}
+	}
+
+	return x, nil
+}
+
+// isSpace reports whether c is one of the (ASCII) spaces that
+// ParseISBN ignores.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
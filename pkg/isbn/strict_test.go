@@ -0,0 +1,87 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseISBNStrict(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	cases := []struct {
+		in      string
+		want13  string
+		pos     int
+		element Element
+		missing bool
+	}{
+		// Accepted
+		{"9780670013951", "9780670013951", -1, 0, false},
+		{"978-0-670-01395-1", "9780670013951", -1, 0, false},
+		{" 978-0-670-01395-1\n", "9780670013951", -1, 0, false},
+		{"0670013951", "9780670013951", -1, 0, false},
+		{"0-670-01395-1", "9780670013951", -1, 0, false},
+		{"0-89686-281-x", "9780896862814", -1, 0, false},
+		{"979-10-12-34567-8", "9791012345678", -1, 0, false},
+		{"88-04-47328-2", "9788804473282", -1, 0, false},
+
+		// Rejected
+		{"97-80670-013951", "", 2, ElementPrefix, false},
+		{"978-0670-01395-1", "", 5, ElementGroup, true},
+		{"978-0-67-001395-1", "", 8, ElementRegistrant, false},
+		{"978-0-670-013951", "", 15, ElementPublication, true},
+		{"978-0-670-01395--1", "", 16, ElementCheckDigit, false},
+		{"978-0-670-01395-1-", "", 17, ElementCheckDigit, false},
+		{"-978-0-670-01395-1", "", 0, ElementPrefix, false},
+		{"978-0--670-01395-1", "", 6, ElementRegistrant, false},
+		{"978 0 670 01395 1", "", 3, ElementPrefix, true},
+		{"978-0-670 01395-1", "", 9, ElementRegistrant, true},
+		{"0-6700-1395-1", "", 5, ElementRegistrant, true},
+		{"0670-013951", "", 1, ElementGroup, true},
+	}
+	for _, c := range cases {
+		x, err := ParseISBNStrict(c.in)
+		if x.ISBN13() != c.want13 {
+			t.Errorf("ParseISBNStrict(%q) == %q, want %q (%v)", c.in, x.ISBN13(), c.want13, err)
+		}
+		if c.pos < 0 {
+			if err != nil {
+				t.Errorf("ParseISBNStrict(%q) error == %v, want nil", c.in, err)
+			}
+			continue
+		}
+
+		if !errors.Is(err, ErrHyphenation) {
+			t.Errorf("ParseISBNStrict(%q) error == %v, want ErrHyphenation", c.in, err)
+			continue
+		}
+		var he *HyphenationError
+		if !errors.As(err, &he) {
+			t.Errorf("ParseISBNStrict(%q) error is not a *HyphenationError", c.in)
+			continue
+		}
+		if he.Pos != c.pos || he.Element != c.element || he.Missing != c.missing {
+			t.Errorf("ParseISBNStrict(%q) == {%d, %s, %t}, want {%d, %s, %t}", c.in, he.Pos, he.Element, he.Missing, c.pos, c.element, c.missing)
+		}
+		if he.Want != "978-0-670-01395-1" && he.Want != "0-670-01395-1" {
+			t.Errorf("ParseISBNStrict(%q) want == %q", c.in, he.Want)
+		}
+	}
+
+	// Errors other than hyphenation are as for ParseISBN
+	_, err := ParseISBNStrict("978-0-590-73205-3")
+	if !errors.Is(err, ErrInvalidCheckDigit) {
+		t.Errorf("ParseISBNStrict() error == %v, want ErrInvalidCheckDigit", err)
+	}
+
+	_, _ = UnloadRangeData()
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"sort"
+)
+
+// CorrectionKind identifies the kind of (typing) error that a
+// Correction corrects.
+type CorrectionKind int
+
+// The kinds of error that SuggestCorrections looks for. The order is
+// also the order in which the corrections are ranked (see
+// SuggestCorrections).
+const (
+	// CorrectionMissingCheckDigit is for an ISBN that is missing its
+	// check digit.
+	CorrectionMissingCheckDigit CorrectionKind = iota
+	// CorrectionTransposition is for an ISBN that has two adjacent
+	// characters transposed.
+	CorrectionTransposition
+	// CorrectionCheckDigit is for an ISBN that has the wrong check
+	// digit.
+	CorrectionCheckDigit
+	// CorrectionSubstitution is for an ISBN that has a single wrong
+	// (non check) digit.
+	CorrectionSubstitution
+)
+
+// String implements the Stringer interface.
+func (k CorrectionKind) String() string {
+	switch k {
+	case CorrectionMissingCheckDigit:
+		return "missing check digit"
+	case CorrectionTransposition:
+		return "transposition"
+	case CorrectionCheckDigit:
+		return "check digit"
+	case CorrectionSubstitution:
+		return "substitution"
+	}
+	return "unknown"
+}
+
+// A Correction is a candidate correction for an ISBN that failed to
+// parse.
+type Correction struct {
+	Kind  CorrectionKind // the kind of error that was corrected
+	Pos   int            // the position of the (first) corrected character
+	Input string         // the corrected ISBN (in compact, normalised form)
+	ISBN  ISBN           // the parsed, corrected, ISBN
+}
+
+// SuggestCorrections lists the likely intended ISBNs for an ISBN that
+// fails to parse using the range data loaded by LoadRangeData.
+func SuggestCorrections(isbn string) ([]Correction, error) {
+	return defaultRangeData.SuggestCorrections(isbn)
+}
+
+// SuggestCorrections lists the likely intended ISBNs for an ISBN that
+// fails to parse using the range data in r. Each candidate correction
+// is for a single error (a substituted digit, two transposed adjacent
+// digits, or a wrong or missing check digit) and only candidates that
+// parse as valid ISBNs are returned.
+//
+// The corrections are ranked by kind (missing check digit,
+// transposition, check digit, then substitution) and then by position.
+// Transpositions are ranked first as, while any single error can be
+// "corrected" into a valid ISBN in several ways, a transposition that
+// produces a valid ISBN is much less likely to be a coincidence. For an
+// ISBN-10 the mod-11 check digit detects all single substitutions and
+// transpositions so the intended ISBN will always be amongst the
+// candidates. For an ISBN-13 the mod-10 check digit does not detect
+// the transposition of adjacent digits that differ by 5.
+//
+// No corrections (and no error) are returned for an ISBN that parses.
+// An error is returned if the ISBN is not the length of an ISBN (with
+// or without the check digit), contains invalid characters, or there
+// is no range data.
+func (r *RangeData) SuggestCorrections(isbn string) ([]Correction, error) {
+
+	isbn = stripISBN(isbn)
+
+	if !r.HasRangeData() {
+		return nil, newParseError(StageRangeData, isbn, -1, ErrNoRangeData)
+	}
+
+	// Missing check digit
+	if len(isbn) == 9 || len(isbn) == 12 {
+		err := chkCharacters(isbn + "0")
+		if err != nil {
+			return nil, err
+		}
+
+		var cd string
+		if len(isbn) == 9 {
+			cd, _ = CalcCheckDigit10(isbn + "0")
+		} else {
+			cd, _ = CalcCheckDigit13(isbn + "0")
+		}
+
+		var ret []Correction
+		ret = r.appendCorrection(ret, CorrectionMissingCheckDigit, len(isbn), isbn+cd)
+		return ret, nil
+	}
+
+	err := chkLength(isbn)
+	if err != nil {
+		return nil, err
+	}
+	err = chkCharacters(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.ParseISBN(isbn)
+	if err == nil {
+		return nil, nil
+	}
+
+	var ret []Correction
+	last := len(isbn) - 1
+	b := []byte(isbn)
+
+	// Wrong check digit
+	var cd string
+	if len(isbn) == 10 {
+		cd, _ = CalcCheckDigit10(isbn)
+	} else {
+		cd, _ = CalcCheckDigit13(isbn)
+	}
+	if cd != isbn[last:] {
+		ret = r.appendCorrection(ret, CorrectionCheckDigit, last, isbn[:last]+cd)
+	}
+
+	// Transposition of adjacent digits (an X check digit can't have
+	// been transposed as it is never valid elsewhere)
+	for i := 0; i < last; i++ {
+		if b[i] == b[i+1] || b[i+1] == 'X' {
+			continue
+		}
+		c := []byte(isbn)
+		c[i], c[i+1] = c[i+1], c[i]
+		ret = r.appendCorrection(ret, CorrectionTransposition, i, string(c))
+	}
+
+	// Substitution of a single (non check) digit
+	for i := 0; i < last; i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == b[i] {
+				continue
+			}
+			c := []byte(isbn)
+			c[i] = d
+			ret = r.appendCorrection(ret, CorrectionSubstitution, i, string(c))
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Kind != ret[j].Kind {
+			return ret[i].Kind < ret[j].Kind
+		}
+		return ret[i].Pos < ret[j].Pos
+	})
+
+	return ret, nil
+}
+
+// appendCorrection appends the candidate correction to corrections if
+// the candidate parses as a valid ISBN.
+func (r *RangeData) appendCorrection(corrections []Correction, kind CorrectionKind, pos int, candidate string) []Correction {
+
+	x, err := r.ParseISBN(candidate)
+	if err != nil {
+		return corrections
+	}
+
+	return append(corrections, Correction{
+		Kind:  kind,
+		Pos:   pos,
+		Input: candidate,
+		ISBN:  x,
+	})
+}
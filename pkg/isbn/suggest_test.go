@@ -0,0 +1,87 @@
+// Copyright 2017 Gregory Siems. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package isbn
+
+import (
+	"testing"
+)
+
+func TestSuggestCorrections(t *testing.T) {
+
+	// Ensure that the range data is loaded
+	ps := prepRangeData()
+	if !ps {
+		t.Errorf("prepRangeData failed")
+	}
+
+	// Test that the intended ISBN is amongst the suggestions
+	cases := []struct {
+		in   string
+		want string
+		kind CorrectionKind
+		pos  int
+	}{
+		{"054792824", "0547928246", CorrectionMissingCheckDigit, 9},
+		{"978054792824", "9780547928241", CorrectionMissingCheckDigit, 12},
+		{"0547928245", "0547928246", CorrectionCheckDigit, 9},
+		{"978-0547928242", "9780547928241", CorrectionCheckDigit, 12},
+		{"0574928246", "0547928246", CorrectionTransposition, 2},
+		{"0547982246", "0547928246", CorrectionTransposition, 5},
+		{"0547928264", "0547928246", CorrectionTransposition, 8},
+		{"9780547982241", "9780547928241", CorrectionTransposition, 8},
+		{"0547938246", "0547928246", CorrectionSubstitution, 5},
+		{"9780547928341", "9780547928241", CorrectionSubstitution, 10},
+		{"0896862813", "089686281X", CorrectionCheckDigit, 9},
+	}
+	for _, c := range cases {
+		got, err := SuggestCorrections(c.in)
+		if err != nil {
+			t.Errorf("SuggestCorrections(%q) == fail, want success (%q)", c.in, err)
+			continue
+		}
+
+		found := false
+		for _, s := range got {
+			if s.Input == c.want && s.Kind == c.kind && s.Pos == c.pos {
+				found = true
+			}
+			if !s.ISBN.IsValid {
+				t.Errorf("SuggestCorrections(%q) suggested invalid %q", c.in, s.Input)
+			}
+		}
+		if !found {
+			t.Errorf("SuggestCorrections(%q) == %v, want %q (%s at %d)", c.in, got, c.want, c.kind, c.pos)
+		}
+	}
+
+	// Transpositions rank ahead of substitutions
+	got, _ := SuggestCorrections("0574928246")
+	if len(got) == 0 || got[0].Kind != CorrectionTransposition {
+		t.Errorf("SuggestCorrections(%q)[0] == %v, want a transposition", "0574928246", got)
+	}
+
+	// Valid ISBNs need no correction, invalid input can't be corrected
+	failures := []struct {
+		in      string
+		wantErr bool
+	}{
+		{"0547928246", false},
+		{"978-0547928241", false},
+		{"05479282", true},
+		{"05479A8246", true},
+		{"", true},
+	}
+	for _, c := range failures {
+		got, err := SuggestCorrections(c.in)
+		if len(got) != 0 {
+			t.Errorf("SuggestCorrections(%q) == %v, want no suggestions", c.in, got)
+		}
+		if (err != nil) != c.wantErr {
+			t.Errorf("SuggestCorrections(%q) error == %v, want error %t", c.in, err, c.wantErr)
+		}
+	}
+
+	_, _ = UnloadRangeData()
+}